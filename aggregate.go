@@ -0,0 +1,420 @@
+package remedy
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultAggregatePageSize is the number of entries fetched per page.
+	defaultAggregatePageSize = 500
+
+	// defaultMaxGroups bounds the memory used by an aggregation.
+	defaultMaxGroups = 10000
+)
+
+// ErrTooManyGroups indicates an aggregation produced more groups than allowed.
+var ErrTooManyGroups = errors.New("remedy: too many aggregation groups")
+
+// metricKind identifies an aggregate function.
+type metricKind string
+
+const (
+	metricCount metricKind = "count"
+	metricSum   metricKind = "sum"
+	metricAvg   metricKind = "avg"
+	metricMin   metricKind = "min"
+	metricMax   metricKind = "max"
+)
+
+// metric is an aggregate function applied to a field.
+type metric struct {
+	kind  metricKind
+	field string
+}
+
+// column returns the result column name of the metric.
+func (m metric) column() string {
+	if m.kind == metricCount {
+		return string(metricCount)
+	}
+
+	return string(m.kind) + "(" + m.field + ")"
+}
+
+// Aggregation computes grouped aggregates over entries on the client, for
+// reports the REST API cannot compute server-side. Entries are streamed page
+// by page with keyset pagination, so entries created or deleted during the
+// run do not shift pages, and only per-group accumulators are kept in memory.
+//
+// Example usage:
+//
+//	result, err := remedy.NewAggregation().
+//	    GroupBy("Status", "Assigned Group").
+//	    Count().
+//	    Avg("Effort Hours").
+//	    Max("Submit Date").
+//	    Run(ctx, client.Entries(), "HPD:Help Desk",
+//	        remedy.WithQualification(`'Submit Date' > "2024-01-01"`))
+//
+// Numeric fields accept numbers and numeric strings. Date fields are
+// recognized from AR timestamps; their Min, Max and Avg are time.Time
+// values. Values that are neither are ignored by all functions but Count.
+type Aggregation struct {
+	groupBy   []string
+	metrics   []metric
+	pageSize  int
+	maxGroups int
+	keyField  string
+}
+
+// NewAggregation creates an aggregation with no grouping or functions.
+func NewAggregation() *Aggregation {
+	return &Aggregation{
+		pageSize:  defaultAggregatePageSize,
+		maxGroups: defaultMaxGroups,
+	}
+}
+
+// GroupBy sets the fields whose distinct value combinations form the groups.
+// Without grouping, the aggregation produces a single row.
+func (a *Aggregation) GroupBy(fields ...string) *Aggregation {
+	a.groupBy = fields
+	return a
+}
+
+// Count adds the number of entries in each group.
+func (a *Aggregation) Count() *Aggregation {
+	a.metrics = append(a.metrics, metric{kind: metricCount})
+	return a
+}
+
+// Sum adds the sum of a numeric field.
+func (a *Aggregation) Sum(field string) *Aggregation {
+	a.metrics = append(a.metrics, metric{kind: metricSum, field: field})
+	return a
+}
+
+// Avg adds the average of a numeric or date field.
+func (a *Aggregation) Avg(field string) *Aggregation {
+	a.metrics = append(a.metrics, metric{kind: metricAvg, field: field})
+	return a
+}
+
+// Min adds the minimum of a numeric or date field.
+func (a *Aggregation) Min(field string) *Aggregation {
+	a.metrics = append(a.metrics, metric{kind: metricMin, field: field})
+	return a
+}
+
+// Max adds the maximum of a numeric or date field.
+func (a *Aggregation) Max(field string) *Aggregation {
+	a.metrics = append(a.metrics, metric{kind: metricMax, field: field})
+	return a
+}
+
+// PageSize sets the number of entries fetched per request. The default is 500.
+func (a *Aggregation) PageSize(n int) *Aggregation {
+	a.pageSize = n
+	return a
+}
+
+// KeyField sets the unique field used for keyset pagination.
+// The default is the entry ID (field 1).
+func (a *Aggregation) KeyField(field string) *Aggregation {
+	a.keyField = field
+	return a
+}
+
+// MaxGroups limits the number of groups kept in memory. Run fails with
+// ErrTooManyGroups when it is exceeded. The default is 10000.
+func (a *Aggregation) MaxGroups(n int) *Aggregation {
+	a.maxGroups = n
+	return a
+}
+
+// AggregateResult is the tabular result of an aggregation. Each row holds
+// the group-by values followed by one value per function, in the order
+// they were added. Rows are sorted by their group-by values: numbers
+// numerically, dates chronologically and other values as text, with
+// empty values first.
+type AggregateResult struct {
+	Columns []string
+	Rows    [][]any
+}
+
+// Run pages through the entries of form matching opts and aggregates them.
+// Field selection, sorting and pagination options are managed by Run;
+// other query options such as WithQualification apply.
+func (a *Aggregation) Run(ctx context.Context, entries EntryServicer, form string, opts ...QueryOption) (*AggregateResult, error) {
+	groups := make(map[string]*aggregateGroup)
+
+	pageSize := a.pageSize
+	if pageSize <= 0 {
+		pageSize = defaultAggregatePageSize
+	}
+
+	keyField, err := a.resolveKeyField(ctx, entries, form)
+	if err != nil {
+		return nil, err
+	}
+
+	queryOpts := append(slices.Clone(opts), WithFields(a.fields(keyField)...), WithLimit(pageSize))
+	for page, err := range KeysetPages(ctx, entries, form, keyField, queryOpts...) {
+		if err != nil {
+			return nil, err
+		}
+		for i := range page {
+			if err := a.add(groups, &page[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return a.result(groups), nil
+}
+
+// resolveKeyField returns the key field set with KeyField, or the entry ID
+// field of form.
+func (a *Aggregation) resolveKeyField(ctx context.Context, entries EntryServicer, form string) (string, error) {
+	if a.keyField != "" {
+		return a.keyField, nil
+	}
+
+	core, err := coreFieldsOf(ctx, entries, form)
+	if err != nil {
+		return "", err
+	}
+
+	return core.id, nil
+}
+
+// fields returns the distinct fields needed by the aggregation, ending
+// with the key field.
+func (a *Aggregation) fields(keyField string) []string {
+	fields := slices.Clone(a.groupBy)
+	for _, m := range a.metrics {
+		if m.field != "" && !slices.Contains(fields, m.field) {
+			fields = append(fields, m.field)
+		}
+	}
+	if !slices.Contains(fields, keyField) {
+		fields = append(fields, keyField)
+	}
+
+	return fields
+}
+
+// add accumulates one entry into its group.
+func (a *Aggregation) add(groups map[string]*aggregateGroup, e *Entry) error {
+	keyParts := make([]string, len(a.groupBy))
+	for i, field := range a.groupBy {
+		keyParts[i] = fmt.Sprint(e.Values[field])
+	}
+	key := strings.Join(keyParts, "\x00")
+
+	g, ok := groups[key]
+	if !ok {
+		if len(groups) >= a.maxGroups {
+			return fmt.Errorf("%w: more than %d", ErrTooManyGroups, a.maxGroups)
+		}
+
+		g = &aggregateGroup{
+			values:  make([]any, len(a.groupBy)),
+			metrics: make([]metricState, len(a.metrics)),
+		}
+		for i, field := range a.groupBy {
+			g.values[i] = e.Values[field]
+		}
+		groups[key] = g
+	}
+
+	g.count++
+	for i, m := range a.metrics {
+		if m.kind != metricCount {
+			g.metrics[i].add(e.Values[m.field])
+		}
+	}
+
+	return nil
+}
+
+// result converts the accumulated groups into a sorted table.
+func (a *Aggregation) result(groups map[string]*aggregateGroup) *AggregateResult {
+	result := &AggregateResult{Columns: slices.Clone(a.groupBy)}
+	for _, m := range a.metrics {
+		result.Columns = append(result.Columns, m.column())
+	}
+
+	sorted := make([]*aggregateGroup, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, g)
+	}
+	slices.SortFunc(sorted, func(x, y *aggregateGroup) int {
+		return slices.CompareFunc(x.values, y.values, compareGroupValues)
+	})
+
+	for _, g := range sorted {
+		row := slices.Clone(g.values)
+		for i, m := range a.metrics {
+			if m.kind == metricCount {
+				row = append(row, g.count)
+				continue
+			}
+			row = append(row, g.metrics[i].value(m.kind))
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	return result
+}
+
+// compareGroupValues orders group-by values: nil first, then numbers and
+// dates by value, and other values by their text.
+func compareGroupValues(a, b any) int {
+	if a == nil || b == nil {
+		switch {
+		case a != nil:
+			return 1
+		case b != nil:
+			return -1
+		default:
+			return 0
+		}
+	}
+
+	if na, ok := numericValue(a); ok {
+		if nb, ok := numericValue(b); ok {
+			return cmp.Compare(na, nb)
+		}
+	}
+	if ta, ok := asTimestamp(a); ok {
+		if tb, ok := asTimestamp(b); ok {
+			return ta.Compare(tb)
+		}
+	}
+
+	return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// aggregateGroup accumulates the entries of one group.
+type aggregateGroup struct {
+	values  []any
+	count   int
+	metrics []metricState
+}
+
+// metricState accumulates the values of a field within a group.
+// Dates are accumulated as Unix seconds.
+type metricState struct {
+	n      int
+	sum    float64
+	min    float64
+	max    float64
+	isTime bool
+}
+
+// add accumulates a value, ignoring values that are neither numbers nor dates.
+func (s *metricState) add(v any) {
+	f, isTime, ok := aggregateValue(v)
+	if !ok {
+		return
+	}
+
+	if s.n == 0 {
+		s.min, s.max, s.isTime = f, f, isTime
+	}
+
+	s.n++
+	s.sum += f
+	s.min = math.Min(s.min, f)
+	s.max = math.Max(s.max, f)
+}
+
+// value returns the result of the function, or nil if no values were seen.
+func (s *metricState) value(kind metricKind) any {
+	if s.n == 0 {
+		return nil
+	}
+
+	var f float64
+	switch kind {
+	case metricSum:
+		return s.sum
+	case metricAvg:
+		f = s.sum / float64(s.n)
+	case metricMin:
+		f = s.min
+	case metricMax:
+		f = s.max
+	case metricCount:
+		return s.n
+	}
+
+	if s.isTime {
+		return time.Unix(int64(f), 0).UTC()
+	}
+
+	return f
+}
+
+// aggregateValue converts numbers, numeric strings and timestamps to float64.
+func aggregateValue(v any) (f float64, isTime, ok bool) {
+	if f, ok := numericValue(v); ok {
+		return f, false, true
+	}
+	if t, ok := asTimestamp(v); ok {
+		return float64(t.Unix()), true, true
+	}
+	if f, ok := numericString(v); ok {
+		return f, false, true
+	}
+
+	return 0, false, false
+}
+
+// WriteCSV writes the result as CSV with a header row. Dates are written
+// in RFC 3339 format and missing values as empty cells.
+func (r *AggregateResult) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(r.Columns); err != nil {
+		return fmt.Errorf("writing csv header: %w", err)
+	}
+
+	record := make([]string, len(r.Columns))
+	for _, row := range r.Rows {
+		for i, v := range row {
+			record[i] = formatCSVValue(v)
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("writing csv row: %w", err)
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// formatCSVValue formats a result value for CSV output.
+func formatCSVValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return val.Format(time.RFC3339)
+	case float64:
+		return formatDecimal(val)
+	default:
+		return fmt.Sprint(val)
+	}
+}
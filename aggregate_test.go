@@ -0,0 +1,158 @@
+package remedy
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// keysetEntriesClient serves entries in keyset pages by Request ID. Each
+// entry gets the Request ID REQnnn from its position.
+func keysetEntriesClient(t *testing.T, entries []map[string]any) *Client {
+	t.Helper()
+
+	for i, values := range entries {
+		values["Request ID"] = fmt.Sprintf("REQ%03d", i)
+	}
+
+	return setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		assert.Equal(t, "Request ID", query.Get("sort"))
+		limit, err := strconv.Atoi(query.Get("limit"))
+		require.NoError(t, err)
+
+		after := ""
+		if _, key, ok := strings.Cut(query.Get("q"), `'Request ID' > "`); ok {
+			after = strings.TrimSuffix(key, `"`)
+		}
+
+		list := EntryList{}
+		for _, values := range entries {
+			if values["Request ID"].(string) > after && len(list.Entries) < limit {
+				list.Entries = append(list.Entries, Entry{Values: values})
+			}
+		}
+
+		return newMockResponse(http.StatusOK, list), nil
+	})
+}
+
+func TestAggregation_Run(t *testing.T) {
+	client := keysetEntriesClient(t, []map[string]any{
+		{"Status": "New", "Effort": 2, "Submit Date": "2024-01-01T10:00:00.000+0000"},
+		{"Status": "Assigned", "Effort": "4", "Submit Date": "2024-01-03T10:00:00.000+0000"},
+		{"Status": "New", "Effort": 4, "Submit Date": "2024-01-05T10:00:00.000+0000"},
+		{"Status": "New", "Effort": nil, "Submit Date": nil},
+	})
+
+	result, err := NewAggregation().
+		GroupBy("Status").
+		Count().
+		Sum("Effort").
+		Avg("Effort").
+		Min("Submit Date").
+		Max("Submit Date").
+		PageSize(3).
+		Run(t.Context(), client.Entries(), "Form")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Status", "count", "sum(Effort)", "avg(Effort)", "min(Submit Date)", "max(Submit Date)"}, result.Columns)
+	assert.Equal(t, [][]any{
+		{"Assigned", 1, 4.0, 4.0,
+			time.Date(2024, 1, 3, 10, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 10, 0, 0, 0, time.UTC)},
+		{"New", 3, 6.0, 3.0,
+			time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC)},
+	}, result.Rows)
+}
+
+func TestAggregation_Run_RequestsNeededFields(t *testing.T) {
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "values(Status,Priority,Effort,Request ID)", req.URL.Query().Get("fields"))
+		assert.Equal(t, `'Priority' = "High"`, req.URL.Query().Get("q"))
+		return newMockResponse(http.StatusOK, EntryList{}), nil
+	})
+
+	result, err := NewAggregation().
+		GroupBy("Status", "Priority").
+		Sum("Effort").
+		Max("Priority").
+		Run(t.Context(), client.Entries(), "Form", WithQualification(`'Priority' = "High"`))
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Rows)
+}
+
+func TestAggregation_Run_TooManyGroups(t *testing.T) {
+	client := keysetEntriesClient(t, []map[string]any{
+		{"Status": "New"},
+		{"Status": "Assigned"},
+		{"Status": "Closed"},
+	})
+
+	_, err := NewAggregation().GroupBy("Status").Count().MaxGroups(2).
+		Run(t.Context(), client.Entries(), "Form")
+
+	require.ErrorIs(t, err, ErrTooManyGroups)
+}
+
+func TestAggregation_Run_NoGroupBy(t *testing.T) {
+	client := keysetEntriesClient(t, []map[string]any{
+		{"Effort": 1.5},
+		{"Effort": 2.5},
+	})
+
+	result, err := NewAggregation().Count().Sum("Effort").
+		Run(t.Context(), client.Entries(), "Form")
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]any{{2, 4.0}}, result.Rows)
+}
+
+func TestAggregation_Run_CountRequestsKeyOnly(t *testing.T) {
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "values(Request ID)", req.URL.Query().Get("fields"))
+		return newMockResponse(http.StatusOK, EntryList{}), nil
+	})
+
+	_, err := NewAggregation().Count().Run(t.Context(), client.Entries(), "Form")
+
+	require.NoError(t, err)
+}
+
+func TestAggregation_Run_SortsTypedGroups(t *testing.T) {
+	client := keysetEntriesClient(t, []map[string]any{
+		{"Priority": 10},
+		{"Priority": 9},
+		{"Priority": nil},
+		{"Priority": 100},
+	})
+
+	result, err := NewAggregation().GroupBy("Priority").Count().PageSize(2).
+		Run(t.Context(), client.Entries(), "Form")
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]any{{nil, 1}, {9.0, 1}, {10.0, 1}, {100.0, 1}}, result.Rows)
+}
+
+func TestAggregateResult_WriteCSV(t *testing.T) {
+	result := &AggregateResult{
+		Columns: []string{"Status", "count", "avg(Effort)", "max(Submit Date)"},
+		Rows: [][]any{
+			{"New", 3, 2.5, time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC)},
+			{"Closed, Resolved", 1, nil, nil},
+		},
+	}
+
+	var sb strings.Builder
+	require.NoError(t, result.WriteCSV(&sb))
+
+	assert.Equal(t, "Status,count,avg(Effort),max(Submit Date)\n"+
+		"New,3,2.5,2024-01-05T10:00:00Z\n"+
+		"\"Closed, Resolved\",1,,\n", sb.String())
+}
@@ -0,0 +1,561 @@
+package remedy
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ArchiveManifestName is the path of the manifest written by Archive.
+const ArchiveManifestName = "manifest.json"
+
+// ArchiveWriter stores the files written by Archive, such as a directory
+// or a tar or zip stream.
+type ArchiveWriter interface {
+	// WriteFile stores the contents of r at name, a slash-separated path.
+	// size is the number of bytes in r, or -1 if unknown.
+	WriteFile(name string, size int64, r io.Reader) error
+
+	// Close completes the archive.
+	Close() error
+}
+
+// ArchiveItem describes an archived attachment.
+type ArchiveItem struct {
+	EntryID  string `json:"entryId"`
+	Field    string `json:"field"`
+	Filename string `json:"filename"`
+
+	// Path is the location of the file in the archive.
+	Path string `json:"path"`
+
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// ArchiveManifest lists the attachments written by an Archive run. It is
+// stored in the archive as ArchiveManifestName.
+type ArchiveManifest struct {
+	Form    string        `json:"form"`
+	Created time.Time     `json:"created"`
+	Items   []ArchiveItem `json:"items"`
+
+	// Skipped is the number of attachments not archived because they were
+	// unchanged since the run recorded in the checkpoint.
+	Skipped int `json:"skipped"`
+}
+
+// ArchiveOption configures Archive.
+type ArchiveOption func(*archiveOptions)
+
+// archiveOptions holds the configuration for Archive.
+type archiveOptions struct {
+	query         []QueryOption
+	fields        []string
+	idField       string
+	checkpoints   CheckpointStore
+	checkpointKey string
+}
+
+// WithArchiveQuery selects the entries to archive. WithQualification
+// filters entries and WithLimit sets the page size.
+func WithArchiveQuery(opts ...QueryOption) ArchiveOption {
+	return func(o *archiveOptions) {
+		o.query = opts
+	}
+}
+
+// WithArchiveFields limits the attachment fields that are archived.
+// By default, all attachment fields in the form metadata are archived.
+func WithArchiveFields(fields ...string) ArchiveOption {
+	return func(o *archiveOptions) {
+		o.fields = fields
+	}
+}
+
+// WithArchiveIDField sets the unique field used to page through entries
+// and to identify them in the archive. The default is the entry ID
+// (field 1).
+func WithArchiveIDField(field string) ArchiveOption {
+	return func(o *archiveOptions) {
+		o.idField = field
+	}
+}
+
+// WithArchiveCheckpoint records the archived attachments in store under
+// key, so a later Archive with the same key only archives attachments that
+// were added or replaced since. The checkpoint is saved after the archive
+// is complete.
+//
+// AR has no version for an attachment, so an attachment is archived again
+// when its file name or size changed or its entry was modified since the
+// last run, even if the entry change did not touch the attachment.
+func WithArchiveCheckpoint(store CheckpointStore, key string) ArchiveOption {
+	return func(o *archiveOptions) {
+		o.checkpoints = store
+		o.checkpointKey = key
+	}
+}
+
+// archiveCheckpoint is the persisted state of Archive: the archived
+// attachment of each entry and field.
+type archiveCheckpoint struct {
+	Entries map[string]map[string]archivedFile `json:"entries"`
+}
+
+// archivedFile identifies an archived attachment in a checkpoint.
+// Modified is the Modified Date of the entry in Unix seconds.
+type archivedFile struct {
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	SHA256   string `json:"sha256"`
+	Modified int64  `json:"modified"`
+}
+
+// Archive downloads every populated attachment field of the entries of a
+// form into dst and writes a manifest listing the entry ID, field, file
+// name, size and SHA-256 of each file. Archive closes dst when done.
+//
+// Example usage:
+//
+//	f, err := os.Create("closed-incidents.tar")
+//	...
+//	q := remedy.NewQuery().And("Status", "=", "Closed").Build()
+//	manifest, err := remedy.Archive(ctx, client, "HPD:Help Desk", remedy.NewTarArchive(f),
+//	    remedy.WithArchiveQuery(remedy.WithQualification(q)),
+//	    remedy.WithArchiveCheckpoint(remedy.NewFileCheckpointStore("checkpoints"), "hpd-attachments"))
+//
+// Files are stored at <entry ID>/<field>/<file name>. Entries are read
+// with keyset pagination and attachments are downloaded one at a time
+// through the client, so the request queue and rate limiter apply. The
+// file names and sizes come from the listed entries, so each attachment
+// takes a single download request.
+// Attachments removed between listing and download are skipped.
+func Archive(ctx context.Context, client RemedyClient, form string, dst ArchiveWriter, opts ...ArchiveOption) (*ArchiveManifest, error) {
+	o := &archiveOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	r := &archiveRun{
+		client:   client,
+		form:     form,
+		dst:      dst,
+		o:        o,
+		manifest: &ArchiveManifest{Form: form, Created: time.Now().UTC(), Items: []ArchiveItem{}},
+	}
+
+	if err := r.run(ctx); err != nil {
+		_ = dst.Close()
+		return nil, err
+	}
+
+	if err := dst.Close(); err != nil {
+		return nil, fmt.Errorf("closing archive: %w", err)
+	}
+	if err := r.saveCheckpoint(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.manifest, nil
+}
+
+// archiveRun is the state of an Archive in progress.
+type archiveRun struct {
+	client   RemedyClient
+	form     string
+	dst      ArchiveWriter
+	o        *archiveOptions
+	core     coreFields
+	state    *archiveCheckpoint
+	manifest *ArchiveManifest
+}
+
+// run archives the attachments of all matching entries and writes the manifest.
+func (r *archiveRun) run(ctx context.Context) error {
+	fields, err := r.attachmentFields(ctx)
+	if err != nil {
+		return err
+	}
+
+	if r.core, err = coreFieldsOf(ctx, r.client.Entries(), r.form); err != nil {
+		return err
+	}
+	if r.o.idField == "" {
+		r.o.idField = r.core.id
+	}
+
+	if err := r.loadCheckpoint(ctx); err != nil {
+		return err
+	}
+
+	if len(fields) > 0 {
+		listFields := fields
+		if r.o.checkpoints != nil {
+			listFields = append(slices.Clone(fields), r.core.modified)
+		}
+
+		query := append(slices.Clone(r.o.query), WithFields(listFields...))
+		for page, err := range KeysetPages(ctx, r.client.Entries(), r.form, r.o.idField, query...) {
+			if err != nil {
+				return fmt.Errorf("listing entries: %w", err)
+			}
+			for i := range page {
+				if err := r.archiveEntry(ctx, &page[i], fields); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	data, err := json.MarshalIndent(r.manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding manifest: %w", err)
+	}
+
+	return r.dst.WriteFile(ArchiveManifestName, int64(len(data)), bytes.NewReader(data))
+}
+
+// attachmentFields returns the fields to archive.
+func (r *archiveRun) attachmentFields(ctx context.Context) ([]string, error) {
+	if len(r.o.fields) > 0 {
+		return r.o.fields, nil
+	}
+
+	fields, err := r.client.Forms().Fields(ctx, r.form)
+	if err != nil {
+		return nil, fmt.Errorf("reading form fields: %w", err)
+	}
+
+	return attachmentFields(fields), nil
+}
+
+// archiveEntry archives the populated attachment fields of an entry,
+// skipping attachments recorded unchanged in the checkpoint.
+func (r *archiveRun) archiveEntry(ctx context.Context, entry *Entry, fields []string) error {
+	id := entry.id(r.o.idField)
+
+	modified, err := r.entryModified(entry)
+	if err != nil {
+		return fmt.Errorf("entry %s: %w", id, err)
+	}
+
+	for _, field := range fields {
+		info, ok, err := entry.AttachmentInfo(field)
+		if err != nil {
+			return fmt.Errorf("entry %s field %s: %w", id, field, err)
+		}
+		if !ok {
+			continue
+		}
+
+		if prev, ok := r.state.Entries[id][field]; ok && prev.Name == info.Name && prev.Size == info.Size && prev.Modified == modified {
+			r.manifest.Skipped++
+			continue
+		}
+
+		if err := r.archiveFile(ctx, id, field, info, modified); err != nil {
+			return fmt.Errorf("archiving entry %s field %s: %w", id, field, err)
+		}
+	}
+
+	return nil
+}
+
+// entryModified returns the Modified Date of an entry in Unix seconds, or 0
+// without a checkpoint.
+func (r *archiveRun) entryModified(entry *Entry) (int64, error) {
+	if r.o.checkpoints == nil {
+		return 0, nil
+	}
+
+	t, err := modifiedDate(entry, r.core.modified)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.Unix(), nil
+}
+
+// archiveFile downloads an attachment into the archive and records it.
+func (r *archiveRun) archiveFile(ctx context.Context, id, field string, info AttachmentInfo, modified int64) error {
+	attachment, err := r.openAttachment(ctx, id, field, info)
+	if errors.Is(err, ErrNoAttachment) || errors.Is(err, ErrNotFound) {
+		return nil // removed since the entry was listed
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = attachment.Close()
+	}()
+
+	item := ArchiveItem{
+		EntryID:  id,
+		Field:    field,
+		Filename: attachment.Name,
+		Path:     archivePath(id, field, attachment.Name),
+	}
+
+	hash := sha256.New()
+	counter := &countingReader{r: io.TeeReader(attachment, hash)}
+	if err := r.dst.WriteFile(item.Path, attachment.Size, counter); err != nil {
+		return err
+	}
+
+	item.Size = counter.n
+	item.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	r.manifest.Items = append(r.manifest.Items, item)
+
+	if r.state.Entries[id] == nil {
+		r.state.Entries[id] = make(map[string]archivedFile)
+	}
+	r.state.Entries[id][field] = archivedFile{Name: item.Filename, Size: item.Size, SHA256: item.SHA256, Modified: modified}
+
+	return nil
+}
+
+// openAttachment opens a listed attachment. The client's attachment service
+// downloads it using the listed info; other AttachmentServicer
+// implementations read it with Get.
+func (r *archiveRun) openAttachment(ctx context.Context, id, field string, info AttachmentInfo) (*Attachment, error) {
+	if svc, ok := r.client.Attachments().(*attachmentService); ok {
+		return svc.download(ctx, r.form, id, field, info)
+	}
+
+	return r.client.Attachments().Get(ctx, r.form, id, field)
+}
+
+// loadCheckpoint reads the attachments archived by earlier runs.
+func (r *archiveRun) loadCheckpoint(ctx context.Context) error {
+	r.state = &archiveCheckpoint{Entries: make(map[string]map[string]archivedFile)}
+	if r.o.checkpoints == nil {
+		return nil
+	}
+
+	data, err := r.o.checkpoints.Load(ctx, r.o.checkpointKey)
+	if errors.Is(err, ErrNoCheckpoint) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("loading archive checkpoint: %w", err)
+	}
+
+	if err := json.Unmarshal(data, r.state); err != nil {
+		return fmt.Errorf("decoding archive checkpoint: %w", err)
+	}
+	if r.state.Entries == nil {
+		r.state.Entries = make(map[string]map[string]archivedFile)
+	}
+
+	return nil
+}
+
+// saveCheckpoint records the archived attachments.
+func (r *archiveRun) saveCheckpoint(ctx context.Context) error {
+	if r.o.checkpoints == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(r.state)
+	if err != nil {
+		return fmt.Errorf("encoding archive checkpoint: %w", err)
+	}
+
+	if err := r.o.checkpoints.Save(ctx, r.o.checkpointKey, data); err != nil {
+		return fmt.Errorf("saving archive checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// archivePath returns the path of an attachment in the archive.
+func archivePath(id, field, filename string) string {
+	return archivePathElement(id) + "/" + archivePathElement(field) + "/" + archivePathElement(filename)
+}
+
+// archivePathElement makes s safe to use as a single path element.
+func archivePathElement(s string) string {
+	s = strings.NewReplacer("/", "_", "\\", "_").Replace(s)
+	if s == "" || s == "." || s == ".." {
+		return "_"
+	}
+
+	return s
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+
+	return n, err
+}
+
+// DirArchive writes archived files into a directory.
+type DirArchive struct {
+	dir string
+}
+
+// NewDirArchive creates an archive writer for dir. Subdirectories are
+// created as needed, and each file is written to a temporary file and
+// renamed into place.
+func NewDirArchive(dir string) *DirArchive {
+	return &DirArchive{dir: dir}
+}
+
+// WriteFile implements ArchiveWriter.
+func (a *DirArchive) WriteFile(name string, _ int64, r io.Reader) error {
+	path := filepath.Join(a.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temporary file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name()) // no-op after a successful rename
+	}()
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Close implements ArchiveWriter.
+func (a *DirArchive) Close() error {
+	return nil
+}
+
+// TarArchive writes archived files to a tar stream.
+type TarArchive struct {
+	tw *tar.Writer
+}
+
+// NewTarArchive creates an archive writer producing a tar stream on w.
+// Closing the archive does not close w.
+func NewTarArchive(w io.Writer) *TarArchive {
+	return &TarArchive{tw: tar.NewWriter(w)}
+}
+
+// WriteFile implements ArchiveWriter. Files of unknown size are buffered
+// in a temporary file, because tar headers record the size.
+func (a *TarArchive) WriteFile(name string, size int64, r io.Reader) error {
+	if size < 0 {
+		spooled, n, err := spool(r)
+		if err != nil {
+			return fmt.Errorf("buffering %s: %w", name, err)
+		}
+		defer func() {
+			_ = spooled.Close()
+			_ = os.Remove(spooled.Name())
+		}()
+		r, size = spooled, n
+	}
+
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o644,
+		Size:     size,
+		ModTime:  time.Now(),
+	}
+	if err := a.tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	if _, err := io.Copy(a.tw, r); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+
+	// Flush fails if fewer bytes than the header size were written
+	if err := a.tw.Flush(); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Close implements ArchiveWriter.
+func (a *TarArchive) Close() error {
+	return a.tw.Close()
+}
+
+// spool copies r into a temporary file and returns it positioned at the
+// start, along with its size.
+func spool(r io.Reader) (*os.File, int64, error) {
+	f, err := os.CreateTemp("", "remedy-archive-*")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	n, err := io.Copy(f, r)
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, 0, err
+	}
+
+	return f, n, nil
+}
+
+// ZipArchive writes archived files to a zip stream.
+type ZipArchive struct {
+	zw *zip.Writer
+}
+
+// NewZipArchive creates an archive writer producing a zip stream on w.
+// Closing the archive does not close w.
+func NewZipArchive(w io.Writer) *ZipArchive {
+	return &ZipArchive{zw: zip.NewWriter(w)}
+}
+
+// WriteFile implements ArchiveWriter.
+func (a *ZipArchive) WriteFile(name string, _ int64, r io.Reader) error {
+	w, err := a.zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+
+	if _, err := io.Copy(w, r); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Close implements ArchiveWriter.
+func (a *ZipArchive) Close() error {
+	return a.zw.Close()
+}
@@ -0,0 +1,256 @@
+package remedy
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// archiveServer serves a form with two attachment fields. files maps
+// entry IDs to fields to file contents; the file name is the field name
+// in lower case with a .txt extension. modified holds the Modified Date
+// of each entry in Unix seconds.
+type archiveServer struct {
+	files      map[string]map[string]string
+	modified   map[string]int64
+	listFields string
+	downloads  int
+
+	// removed names an attachment, as "<entry ID>/<field>", that is listed
+	// but deleted before it is downloaded.
+	removed string
+}
+
+func newArchiveServer() *archiveServer {
+	return &archiveServer{
+		files: map[string]map[string]string{
+			"REQ1": {"Screenshot": "png data"},
+			"REQ2": {"Screenshot": "more png data", "Log": "log lines"},
+			"REQ3": {},
+		},
+		modified:   map[string]int64{"REQ1": 1714550400, "REQ2": 1714550400, "REQ3": 1714550400},
+		listFields: "values(Screenshot,Log,Request ID)",
+	}
+}
+
+// values returns the attachment field values of an entry.
+func (s *archiveServer) values(id string) map[string]any {
+	values := map[string]any{"Request ID": id, "Modified Date": s.modified[id], "Screenshot": nil, "Log": nil}
+	for field, data := range s.files[id] {
+		values[field] = map[string]any{"name": strings.ToLower(field) + ".txt", "sizeBytes": len(data)}
+	}
+
+	return values
+}
+
+func (s *archiveServer) handle(t *testing.T) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		rest, _ := strings.CutPrefix(req.URL.Path, "/api/arsys/v1/")
+		parts := strings.Split(rest, "/")
+
+		switch {
+		case rest == "fields/Form":
+			return newMockResponse(http.StatusOK, []Field{
+				{ID: 1, Name: "Request ID", DataType: DataTypeCharacter},
+				{ID: 536870913, Name: "Screenshot", DataType: DataTypeAttachment},
+				{ID: 536870914, Name: "Log", DataType: DataTypeAttachment},
+			}), nil
+		case rest == "entry/Form":
+			assert.Equal(t, s.listFields, req.URL.Query().Get("fields"))
+			var list EntryList
+			for _, id := range slices.Sorted(maps.Keys(s.files)) {
+				list.Entries = append(list.Entries, Entry{Values: s.values(id)})
+			}
+			return newMockResponse(http.StatusOK, list), nil
+		case len(parts) == 5 && parts[3] == "attach":
+			s.downloads++
+			if parts[2]+"/"+parts[4] == s.removed {
+				return newMockResponse(http.StatusNotFound, []apiErrorResponse{
+					{MessageType: "ERROR", MessageText: "Entry does not exist", MessageNumber: 302},
+				}), nil
+			}
+			data := s.files[parts[2]][parts[4]]
+			return downloadResponse([]byte(data), "text/plain", true)(), nil
+		default:
+			t.Errorf("unexpected request %s", req.URL)
+			return newMockResponse(http.StatusNotFound, nil), nil
+		}
+	}
+}
+
+var wantArchiveFiles = map[string]string{
+	"REQ1/Screenshot/screenshot.txt": "png data",
+	"REQ2/Screenshot/screenshot.txt": "more png data",
+	"REQ2/Log/log.txt":               "log lines",
+}
+
+// checkManifest compares the manifest items with the archived files.
+func checkManifest(t *testing.T, manifest *ArchiveManifest, files map[string]string) {
+	t.Helper()
+
+	require.Len(t, manifest.Items, len(files))
+	for _, item := range manifest.Items {
+		data, ok := files[item.Path]
+		require.True(t, ok, "unexpected item %s", item.Path)
+		assert.Equal(t, int64(len(data)), item.Size)
+		assert.Equal(t, sha256Hex([]byte(data)), item.SHA256)
+		assert.Equal(t, strings.ToLower(item.Field)+".txt", item.Filename)
+	}
+}
+
+func TestArchive_Tar(t *testing.T) {
+	server := newArchiveServer()
+	client := setupAuthenticatedClient(t, server.handle(t))
+
+	var buf bytes.Buffer
+	manifest, err := Archive(t.Context(), client, "Form", NewTarArchive(&buf))
+
+	require.NoError(t, err)
+	checkManifest(t, manifest, wantArchiveFiles)
+	assert.Equal(t, "Form", manifest.Form)
+
+	files := make(map[string]string)
+	tr := tar.NewReader(&buf)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(data)
+	}
+
+	var stored ArchiveManifest
+	require.NoError(t, json.Unmarshal([]byte(files[ArchiveManifestName]), &stored))
+	assert.Equal(t, manifest.Items, stored.Items)
+
+	delete(files, ArchiveManifestName)
+	assert.Equal(t, wantArchiveFiles, files)
+}
+
+func TestArchive_DownloadsListedAttachments(t *testing.T) {
+	server := newArchiveServer()
+	server.removed = "REQ2/Log"
+	client := setupAuthenticatedClient(t, server.handle(t))
+
+	manifest, err := Archive(t.Context(), client, "Form", NewDirArchive(t.TempDir()))
+
+	// Attachments are downloaded with the listed field values, without
+	// reading each entry again; one removed since listing is skipped.
+	require.NoError(t, err)
+	assert.Equal(t, 3, server.downloads)
+	checkManifest(t, manifest, map[string]string{
+		"REQ1/Screenshot/screenshot.txt": "png data",
+		"REQ2/Screenshot/screenshot.txt": "more png data",
+	})
+}
+
+func TestArchive_Zip(t *testing.T) {
+	server := newArchiveServer()
+	server.listFields = "values(Log,Request ID)"
+	client := setupAuthenticatedClient(t, server.handle(t))
+
+	var buf bytes.Buffer
+	manifest, err := Archive(t.Context(), client, "Form", NewZipArchive(&buf),
+		WithArchiveFields("Log"))
+
+	require.NoError(t, err)
+	checkManifest(t, manifest, map[string]string{"REQ2/Log/log.txt": "log lines"})
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(zr.File))
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	assert.ElementsMatch(t, []string{"REQ2/Log/log.txt", ArchiveManifestName}, names)
+}
+
+func TestArchive_DirIncremental(t *testing.T) {
+	server := newArchiveServer()
+	server.listFields = "values(Screenshot,Log,Modified Date,Request ID)"
+	client := setupAuthenticatedClient(t, server.handle(t))
+	dir := t.TempDir()
+	store := NewMemoryCheckpointStore()
+
+	manifest, err := Archive(t.Context(), client, "Form", NewDirArchive(dir),
+		WithArchiveCheckpoint(store, "attachments"))
+	require.NoError(t, err)
+	checkManifest(t, manifest, wantArchiveFiles)
+
+	for path, want := range wantArchiveFiles {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		require.NoError(t, err)
+		assert.Equal(t, want, string(data))
+	}
+
+	// nothing changed: no downloads
+	server.downloads = 0
+	manifest, err = Archive(t.Context(), client, "Form", NewDirArchive(dir),
+		WithArchiveCheckpoint(store, "attachments"))
+	require.NoError(t, err)
+	assert.Empty(t, manifest.Items)
+	assert.Equal(t, 3, manifest.Skipped)
+	assert.Equal(t, 0, server.downloads)
+
+	// a replaced and a new attachment are archived, including a
+	// replacement with the same name and size
+	server.files["REQ1"]["Screenshot"] = "new png data"
+	server.files["REQ2"]["Log"] = "log LINES"
+	server.files["REQ3"]["Log"] = "first log"
+	for _, id := range []string{"REQ1", "REQ2", "REQ3"} {
+		server.modified[id] += 60
+	}
+	manifest, err = Archive(t.Context(), client, "Form", NewDirArchive(dir),
+		WithArchiveCheckpoint(store, "attachments"))
+	require.NoError(t, err)
+	checkManifest(t, manifest, map[string]string{
+		"REQ1/Screenshot/screenshot.txt": "new png data",
+		"REQ2/Screenshot/screenshot.txt": "more png data",
+		"REQ2/Log/log.txt":               "log LINES",
+		"REQ3/Log/log.txt":               "first log",
+	})
+	assert.Zero(t, manifest.Skipped)
+}
+
+func TestTarArchive_UnknownSize(t *testing.T) {
+	var buf bytes.Buffer
+	archive := NewTarArchive(&buf)
+
+	require.NoError(t, archive.WriteFile("a/b.txt", -1, io.MultiReader(strings.NewReader("data"))))
+	require.NoError(t, archive.Close())
+
+	tr := tar.NewReader(&buf)
+	header, err := tr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "a/b.txt", header.Name)
+	assert.Equal(t, int64(4), header.Size)
+}
+
+func TestTarArchive_SizeMismatch(t *testing.T) {
+	archive := NewTarArchive(io.Discard)
+
+	require.Error(t, archive.WriteFile("a.txt", 10, strings.NewReader("short")))
+}
+
+func TestArchivePath(t *testing.T) {
+	assert.Equal(t, "INC1/Work_Log/.._.._etc_passwd", archivePath("INC1", "Work/Log", "../../etc/passwd"))
+	assert.Equal(t, "INC1/Log/_", archivePath("INC1", "Log", ".."))
+	assert.Equal(t, "INC1/Log/a_b.txt", archivePath("INC1", "Log", `a\b.txt`))
+}
@@ -0,0 +1,112 @@
+package remedy
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Associations retrieves the entries related to an entry through the named
+// association. Query options filter, sort and page the related entries.
+// With WithSelectionMapping, selection values are converted using the
+// metadata of the related form, identified by each entry's self link.
+func (s *entryService) Associations(ctx context.Context, form, entryID, association string, opts ...QueryOption) (*EntryList, error) {
+	if form == "" {
+		return nil, ErrEmptyFormName
+	}
+	if entryID == "" {
+		return nil, ErrEmptyEntryID
+	}
+	if association == "" {
+		return nil, ErrEmptyAssociationName
+	}
+
+	path := associationPath(form, entryID, association)
+	params := buildQueryParams(opts)
+
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var list EntryList
+	if err := s.client.getJSON(ctx, path, &list); err != nil {
+		return nil, fmt.Errorf("listing associated entries: %w", err)
+	}
+
+	if err := s.mapRelated(ctx, list.Entries); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+// mapRelated converts the selection values of entries returned for an
+// association, using the metadata of the form named in each entry's self
+// link. Entries without a self link are left as returned. It loads
+// metadata, so it must be called without holding the request queue.
+func (s *entryService) mapRelated(ctx context.Context, entries []Entry) error {
+	if s.client.selectionFormat == SelectionAsIs {
+		return nil
+	}
+
+	for i := range entries {
+		e := &entries[i]
+		if form := e.form(); form != "" {
+			selections, err := s.client.selectionsFor(ctx, form)
+			if err != nil {
+				return err
+			}
+			selections.apply(e.Values, s.client.selectionFormat)
+		}
+		if err := s.mapEmbedded(ctx, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mapEmbedded converts the selection values of the associated entries
+// embedded in e by WithExpand.
+func (s *entryService) mapEmbedded(ctx context.Context, e *Entry) error {
+	for _, entries := range e.Embedded {
+		if err := s.mapRelated(ctx, entries); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mapEmbeddedCached converts the selection values of the entries embedded
+// in e like mapEmbedded, but only with selection maps that are already
+// loaded, so it can be called while holding the request queue. Entries of
+// forms whose metadata has not been loaded are left as returned.
+func (s *entryService) mapEmbeddedCached(e *Entry) {
+	if s.client.selectionFormat == SelectionAsIs {
+		return
+	}
+
+	for _, entries := range e.Embedded {
+		for i := range entries {
+			related := &entries[i]
+			if selections, ok := s.client.forms.cachedSelections(related.form()); ok {
+				selections.apply(related.Values, s.client.selectionFormat)
+			}
+			s.mapEmbeddedCached(related)
+		}
+	}
+}
+
+// Associated returns the entries embedded for the named association when the
+// entry was retrieved with WithExpand. It returns false if the association
+// was not expanded.
+func (e *Entry) Associated(association string) ([]Entry, bool) {
+	entries, ok := e.Embedded[association]
+	return entries, ok
+}
+
+// associationPath builds the API path for the associations of an entry.
+func associationPath(form, entryID, association string) string {
+	return entryIDPath(form, entryID) + "/assoc/" + url.PathEscape(association)
+}
@@ -0,0 +1,123 @@
+package remedy
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntryService_Associations(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "/api/arsys/v1/entry/HPD:Help%20Desk/INC1/assoc/HPD:INC:Work%20Info", req.URL.EscapedPath())
+		assert.Equal(t, "10", req.URL.Query().Get("limit"))
+
+		return newMockResponse(http.StatusOK, EntryList{Entries: []Entry{
+			{Values: map[string]any{"Work Log ID": "WLG1"}},
+		}}), nil
+	})
+
+	list, err := client.Entries().Associations(t.Context(), "HPD:Help Desk", "INC1", "HPD:INC:Work Info", WithLimit(10))
+
+	require.NoError(t, err)
+	require.Len(t, list.Entries, 1)
+	assert.Equal(t, "WLG1", list.Entries[0].Values["Work Log ID"])
+}
+
+func TestEntryService_Associations_EmptyAssociationReturnsError(t *testing.T) {
+	client := New("https://remedy.example.com")
+
+	_, err := client.Entries().Associations(t.Context(), "Form", "ID", "")
+
+	assert.ErrorIs(t, err, ErrEmptyAssociationName)
+}
+
+func TestEntryService_Get_WithExpandDecodesEmbedded(t *testing.T) {
+	body := []byte(`{
+		"values": {"Incident Number": "INC1"},
+		"_embedded": {
+			"HPD:INC:Work Info": [
+				{"values": {"Work Log ID": "WLG1"}},
+				{"values": {"Work Log ID": "WLG2"}}
+			]
+		}
+	}`)
+
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "HPD:INC:Work Info", req.URL.Query().Get("expand"))
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader(body)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	entry, err := client.Entries().Get(t.Context(), "HPD:Help Desk", "INC1", WithExpand("HPD:INC:Work Info"))
+	require.NoError(t, err)
+
+	workInfo, ok := entry.Associated("HPD:INC:Work Info")
+	require.True(t, ok)
+	require.Len(t, workInfo, 2)
+	assert.Equal(t, "WLG2", workInfo[1].Values["Work Log ID"])
+
+	_, ok = entry.Associated("Other")
+	assert.False(t, ok)
+}
+
+func TestEntryService_Associations_MapsSelections(t *testing.T) {
+	var metadata []string
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		if form, ok := strings.CutPrefix(req.URL.Path, "/api/arsys/v1/fields/"); ok {
+			metadata = append(metadata, form)
+			return newMockResponse(http.StatusOK, []Field{testStatusField}), nil
+		}
+
+		return newMockResponse(http.StatusOK, EntryList{Entries: []Entry{
+			{
+				Values: map[string]any{"Status": 1},
+				Links:  []Link{{Rel: "self", Href: "https://remedy.example.com/api/arsys/v1/entry/HPD:WorkLog/WLG1"}},
+			},
+			{Values: map[string]any{"Status": 4}}, // no self link: left as returned
+		}}), nil
+	})
+	client.selectionFormat = SelectionLabels
+
+	list, err := client.Entries().Associations(t.Context(), "HPD:Help Desk", "INC1", "HPD:INC:Work Info")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Assigned", list.Entries[0].Values["Status"])
+	assert.InDelta(t, 4, list.Entries[1].Values["Status"], 0)
+	assert.Equal(t, []string{"HPD:WorkLog"}, metadata)
+}
+
+func TestEntryService_Get_MapsEmbeddedSelections(t *testing.T) {
+	body := []byte(`{
+		"values": {"Status": 0},
+		"_embedded": {
+			"HPD:INC:Work Info": [{
+				"values": {"Status": 4},
+				"_links": [{"rel": "self", "href": "https://remedy.example.com/api/arsys/v1/entry/HPD:Work%20Log/WLG1"}]
+			}]
+		}
+	}`)
+
+	client := newSelectionMappingClient(t, SelectionLabels, func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader(body)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	entry, err := client.Entries().Get(t.Context(), "HPD:Help Desk", "INC1", WithExpand("HPD:INC:Work Info"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "New", entry.Values["Status"])
+	assert.Equal(t, "Closed", entry.Embedded["HPD:INC:Work Info"][0].Values["Status"])
+}
@@ -1,38 +1,238 @@
 package remedy
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"path"
+	"time"
 )
 
+// sniffLen is the number of bytes used to detect the content type of an
+// attachment, as read by http.DetectContentType.
+const sniffLen = 512
+
+// Errors reported by attachment operations.
+var (
+	// ErrAttachmentTooLarge indicates an attachment exceeds the size set
+	// with WithMaxAttachmentSize.
+	ErrAttachmentTooLarge = errors.New("remedy: attachment too large")
+
+	// ErrNoAttachment indicates the attachment field of an entry is empty.
+	ErrNoAttachment = errors.New("remedy: no attachment")
+
+	// ErrInvalidAttachment indicates a field value could not be parsed as
+	// an attachment.
+	ErrInvalidAttachment = errors.New("remedy: invalid attachment value")
+)
+
+// AttachmentInfo describes the file stored in an attachment field, as
+// returned in Entry.Values.
+type AttachmentInfo struct {
+	// Name is the file name of the attachment.
+	Name string
+
+	// Size is the size of the attachment in bytes, or -1 if unknown.
+	Size int64
+}
+
+// attachmentJSON is the wire format of an attachment field value.
+type attachmentJSON struct {
+	Name      string `json:"name"`
+	SizeBytes *int64 `json:"sizeBytes"`
+}
+
+// ParseAttachmentInfo converts an attachment field value from Entry.Values
+// into an AttachmentInfo. It returns false if the value is nil (the field
+// has no attachment).
+func ParseAttachmentInfo(v any) (AttachmentInfo, bool, error) {
+	switch val := v.(type) {
+	case nil:
+		return AttachmentInfo{}, false, nil
+	case AttachmentInfo:
+		return val, true, nil
+	case map[string]any:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return AttachmentInfo{}, false, fmt.Errorf("%w: %w", ErrInvalidAttachment, err)
+		}
+
+		var wire attachmentJSON
+		if err := json.Unmarshal(data, &wire); err != nil {
+			return AttachmentInfo{}, false, fmt.Errorf("%w: %w", ErrInvalidAttachment, err)
+		}
+
+		info := AttachmentInfo{Name: wire.Name, Size: -1}
+		if wire.SizeBytes != nil {
+			info.Size = *wire.SizeBytes
+		}
+		return info, true, nil
+	default:
+		return AttachmentInfo{}, false, fmt.Errorf("%w: expected object, got %T", ErrInvalidAttachment, v)
+	}
+}
+
+// AttachmentInfo parses the named attachment field of the entry.
+// It returns false if the field has no attachment.
+func (e *Entry) AttachmentInfo(field string) (AttachmentInfo, bool, error) {
+	return ParseAttachmentInfo(e.Values[field])
+}
+
+// Attachment is a downloaded attachment. It reads the file contents and
+// must be closed by the caller.
+type Attachment struct {
+	// Name is the file name stored in the attachment field.
+	Name string
+
+	// Size is the size in bytes from Content-Length or the attachment
+	// field, or -1 if unknown.
+	Size int64
+
+	// ContentType is the MIME type from the Content-Type header. If the
+	// server does not send one, or sends the generic
+	// application/octet-stream, it is derived from the file name or
+	// detected from the first bytes of the file.
+	ContentType string
+
+	r    io.Reader
+	body io.Closer
+}
+
+// Read implements io.Reader. It fails with ErrAttachmentTooLarge once more
+// bytes than the maximum attachment size have been read.
+func (a *Attachment) Read(p []byte) (int, error) {
+	return a.r.Read(p)
+}
+
+// Close closes the response body and releases its resources.
+func (a *Attachment) Close() error {
+	return a.body.Close()
+}
+
 // attachmentService implements AttachmentServicer for file operations.
 type attachmentService struct {
 	client *Client
 }
 
-// Get retrieves an attachment from an entry.
-// The caller is responsible for closing the returned ReadCloser.
-func (s *attachmentService) Get(ctx context.Context, form, entryID, fieldName string) (io.ReadCloser, error) {
-	if err := s.client.acquireAndRateLimit(ctx); err != nil {
+// Get retrieves an attachment from an entry. The file name and size are
+// read from the attachment field before the download starts.
+// The caller is responsible for closing the returned Attachment.
+func (s *attachmentService) Get(ctx context.Context, form, entryID, fieldName string) (*Attachment, error) {
+	if fieldName == "" {
+		return nil, ErrEmptyFieldName
+	}
+
+	info, err := s.info(ctx, form, entryID, fieldName)
+	if err != nil {
 		return nil, err
 	}
-	defer s.client.queue.Release()
 
-	path := attachmentPath(form, entryID, fieldName)
+	return s.download(ctx, form, entryID, fieldName, info)
+}
+
+// download opens an attachment whose field value was already read, such as
+// from a listed entry, so only the file itself is requested.
+func (s *attachmentService) download(ctx context.Context, form, entryID, fieldName string, info AttachmentInfo) (*Attachment, error) {
+	if err := s.client.checkAttachmentSize(info.Size); err != nil {
+		return nil, err
+	}
+
+	body, resp, err := s.open(ctx, attachmentPath(form, entryID, fieldName), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := &Attachment{
+		Name:        info.Name,
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+		body:        body,
+	}
+	if attachment.Size < 0 {
+		attachment.Size = info.Size
+	}
+	if err := s.client.checkAttachmentSize(attachment.Size); err != nil {
+		_ = body.Close()
+		return nil, err
+	}
+
+	r := s.client.limitAttachment(body, 0)
+	if genericContentType(attachment.ContentType) {
+		attachment.ContentType = mime.TypeByExtension(path.Ext(info.Name))
+	}
+	if attachment.ContentType == "" {
+		buffered := bufio.NewReaderSize(r, sniffLen)
+		head, _ := buffered.Peek(sniffLen) // read errors are returned by Read
+		attachment.ContentType = http.DetectContentType(head)
+		r = buffered
+	}
+	attachment.r = r
+
+	return attachment, nil
+}
+
+// genericContentType reports whether contentType is missing or the
+// application/octet-stream default that servers send for any binary file.
+func genericContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/octet-stream"
+}
+
+// info reads the attachment field value and checks the size against the
+// maximum attachment size. It returns ErrNoAttachment if the field is empty.
+func (s *attachmentService) info(ctx context.Context, form, entryID, fieldName string) (AttachmentInfo, error) {
+	// Read the field value before acquiring the queue, which is not reentrant
+	entry, err := s.client.entries.Get(ctx, form, entryID, WithFields(fieldName))
+	if err != nil {
+		return AttachmentInfo{}, fmt.Errorf("reading attachment field: %w", err)
+	}
+
+	info, ok, err := entry.AttachmentInfo(fieldName)
+	if err != nil {
+		return AttachmentInfo{}, err
+	}
+	if !ok {
+		return AttachmentInfo{}, fmt.Errorf("%w: field %s of entry %s is empty", ErrNoAttachment, fieldName, entryID)
+	}
+	if err := s.client.checkAttachmentSize(info.Size); err != nil {
+		return AttachmentInfo{}, err
+	}
+
+	return info, nil
+}
+
+// open sends a GET request for an attachment and returns its body. A
+// positive offset requests the remainder of the file with a Range header.
+// The request context is canceled when the body is closed.
+func (s *attachmentService) open(ctx context.Context, path string, offset int64) (io.ReadCloser, *http.Response, error) {
+	if err := s.client.acquireAndRateLimit(ctx); err != nil {
+		return nil, nil, err
+	}
+	defer s.client.queue.Release()
 
 	req, cancel, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("creating attachment request: %w", err)
+		return nil, nil, fmt.Errorf("creating attachment request: %w", err)
+	}
+
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
 	}
 
 	resp, err := s.client.do(req)
 	if err != nil {
 		cancel()
-		return nil, fmt.Errorf("fetching attachment: %w", err)
+		return nil, nil, fmt.Errorf("fetching attachment: %w", err)
 	}
 
 	if resp.StatusCode >= http.StatusBadRequest {
@@ -40,21 +240,44 @@ func (s *attachmentService) Get(ctx context.Context, form, entryID, fieldName st
 		apiErr := s.client.parseAPIError(resp)
 		_ = resp.Body.Close()
 		cancel()
-		return nil, apiErr
+		return nil, nil, apiErr
 	}
 
 	// Return body for caller to read - they must close it
 	return &attachmentReader{
 		ReadCloser: resp.Body,
 		cancel:     cancel,
-	}, nil
+	}, resp, nil
 }
 
 // Upload uploads an attachment to an entry field.
-func (s *attachmentService) Upload(ctx context.Context, form, entryID, fieldName, filename string, data io.Reader) error {
-	if err := s.client.acquireAndRateLimit(ctx); err != nil {
+//
+// Failures while reading data, rejections by the server and context
+// cancellation are returned as an *UploadError of kind ErrUploadSource,
+// ErrUploadRejected or ErrUploadCanceled. Cancellation includes waiting in
+// the request queue and exceeding the request timeout set by WithTimeout.
+func (s *attachmentService) Upload(ctx context.Context, form, entryID, fieldName, filename string, data io.Reader, opts ...UploadOption) error {
+	o := &uploadOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	total := readerSize(data)
+	if err := s.client.checkAttachmentSize(total); err != nil {
 		return err
 	}
+
+	source := &uploadSource{r: data, total: total, progress: o.progress}
+	if o.stats != nil {
+		start := time.Now()
+		defer func() {
+			*o.stats = UploadStats{Bytes: source.bytesSent(), Duration: time.Since(start)}
+		}()
+	}
+
+	if err := s.client.acquireAndRateLimit(ctx); err != nil {
+		return classifyUpload(ctx, err, nil, source)
+	}
 	defer s.client.queue.Release()
 
 	// Create multipart form
@@ -75,8 +298,9 @@ func (s *attachmentService) Upload(ctx context.Context, form, entryID, fieldName
 			return
 		}
 
-		if _, err := io.Copy(part, data); err != nil {
-			_ = writer.Close() // Clean up multipart writer on error
+		if _, err := io.Copy(part, s.client.limitAttachment(source, 0)); err != nil {
+			// Abort the request so the server does not store a truncated file
+			_ = pw.CloseWithError(err)
 			errCh <- fmt.Errorf("copying data: %w", err)
 			return
 		}
@@ -97,37 +321,114 @@ func (s *attachmentService) Upload(ctx context.Context, form, entryID, fieldName
 
 	resp, err := s.client.do(req)
 	if err != nil {
-		return wrapUploadError(err, errCh)
+		_ = pr.CloseWithError(err) // Unblock the writer goroutine
+		return wrapUploadError(ctx, err, errCh, source)
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
-	// Wait for multipart writer to complete
-	if writeErr := <-errCh; writeErr != nil {
-		return writeErr
+	if resp.StatusCode >= http.StatusBadRequest {
+		// The server may reject the upload before reading the body
+		_ = pr.Close()
+		return &UploadError{Kind: ErrUploadRejected, Err: s.client.parseAPIError(resp), Sent: source.bytesSent()}
 	}
 
-	if resp.StatusCode >= http.StatusBadRequest {
-		return s.client.parseAPIError(resp)
+	// Wait for multipart writer to complete
+	if writeErr := <-errCh; writeErr != nil {
+		return classifyUpload(ctx, writeErr, writeErr, source)
 	}
 
 	return nil
 }
 
-// wrapUploadError wraps an HTTP error with any write error from the multipart goroutine.
-func wrapUploadError(httpErr error, errCh <-chan error) error {
+// wrapUploadError wraps an HTTP error with any write error from the
+// multipart goroutine, and classifies reader failures and cancellation
+// of uploads from source, which may be nil.
+func wrapUploadError(ctx context.Context, httpErr error, errCh <-chan error, source *uploadSource) error {
 	// Drain the error channel to capture any write error
 	// The goroutine will eventually complete due to pipe closure
+	var writeErr error
 	select {
-	case writeErr := <-errCh:
-		if writeErr != nil {
-			return fmt.Errorf("uploading attachment: %w (write error: %w)", httpErr, writeErr)
-		}
+	case writeErr = <-errCh:
 	default:
-		// Goroutine hasn't written yet, just return the HTTP error
+		// Goroutine hasn't written yet, just use the HTTP error
 	}
-	return fmt.Errorf("uploading attachment: %w", httpErr)
+
+	return classifyUpload(ctx, httpErr, writeErr, source)
+}
+
+// Delete removes the attachment from a field by setting the field to null.
+func (s *attachmentService) Delete(ctx context.Context, form, entryID, fieldName string) error {
+	if fieldName == "" {
+		return ErrEmptyFieldName
+	}
+
+	if err := s.client.entries.Update(ctx, form, entryID, map[string]any{fieldName: nil}); err != nil {
+		return fmt.Errorf("deleting attachment: %w", err)
+	}
+
+	return nil
+}
+
+// AttachmentField describes an attachment field of an entry, as returned
+// by List. For fields without an attachment, Attached is false and the
+// embedded AttachmentInfo is empty.
+type AttachmentField struct {
+	// Field is the name of the attachment field.
+	Field string
+
+	AttachmentInfo
+
+	// Attached reports whether the field holds an attachment.
+	Attached bool
+}
+
+// List returns the attachment fields of an entry, in the order of the
+// form's field metadata, with the names and sizes of their attachments.
+// Attachment fields are identified from the form metadata.
+func (s *attachmentService) List(ctx context.Context, form, entryID string) ([]AttachmentField, error) {
+	if entryID == "" {
+		return nil, ErrEmptyEntryID
+	}
+
+	fields, err := s.client.forms.Fields(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+
+	names := attachmentFields(fields)
+	if len(names) == 0 {
+		return []AttachmentField{}, nil
+	}
+
+	entry, err := s.client.entries.Get(ctx, form, entryID, WithFields(names...))
+	if err != nil {
+		return nil, fmt.Errorf("reading attachment fields: %w", err)
+	}
+
+	result := make([]AttachmentField, 0, len(names))
+	for _, name := range names {
+		info, ok, err := entry.AttachmentInfo(name)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		result = append(result, AttachmentField{Field: name, AttachmentInfo: info, Attached: ok})
+	}
+
+	return result, nil
+}
+
+// attachmentFields returns the names of the attachment fields in fields.
+func attachmentFields(fields []Field) []string {
+	var names []string
+	for _, f := range fields {
+		if f.DataType == DataTypeAttachment {
+			names = append(names, f.Name)
+		}
+	}
+
+	return names
 }
 
 // attachmentPath builds the path for attachment operations.
@@ -147,3 +448,80 @@ func (r *attachmentReader) Close() error {
 
 	return err
 }
+
+// checkAttachmentSize returns ErrAttachmentTooLarge if a known size
+// exceeds the maximum attachment size.
+func (c *Client) checkAttachmentSize(size int64) error {
+	if c.maxAttachmentSize > 0 && size > c.maxAttachmentSize {
+		return fmt.Errorf("%w: %d bytes exceeds limit of %d bytes", ErrAttachmentTooLarge, size, c.maxAttachmentSize)
+	}
+
+	return nil
+}
+
+// limitAttachment wraps r, which continues an attachment after offset
+// bytes, to fail once the attachment exceeds the maximum attachment size.
+func (c *Client) limitAttachment(r io.Reader, offset int64) io.Reader {
+	if c.maxAttachmentSize <= 0 {
+		return r
+	}
+
+	return &sizeLimitReader{r: r, remaining: c.maxAttachmentSize - offset, limit: c.maxAttachmentSize}
+}
+
+// sizeLimitReader reads up to limit bytes and fails with
+// ErrAttachmentTooLarge if the underlying reader has more.
+type sizeLimitReader struct {
+	r         io.Reader
+	remaining int64
+	limit     int64
+	err       error
+}
+
+func (l *sizeLimitReader) Read(p []byte) (int, error) {
+	if l.err != nil {
+		return 0, l.err
+	}
+
+	if l.remaining <= 0 {
+		// Probe for data beyond the limit
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			l.err = fmt.Errorf("%w: exceeds limit of %d bytes", ErrAttachmentTooLarge, l.limit)
+			return 0, l.err
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+
+	return n, err
+}
+
+// readerSize returns the number of bytes remaining in r, or -1 if unknown.
+func readerSize(r io.Reader) int64 {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return int64(v.Len())
+	case io.Seeker:
+		current, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		end, err := v.Seek(0, io.SeekEnd)
+		if err != nil {
+			return -1
+		}
+		if _, err := v.Seek(current, io.SeekStart); err != nil {
+			return -1
+		}
+		return end - current
+	default:
+		return -1
+	}
+}
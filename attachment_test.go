@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -53,7 +54,10 @@ func TestAttachmentService_Get_ErrorReturnsAPIErrorDetails(t *testing.T) {
 	errorBody, err := json.Marshal([]apiErrorResponse{expectedError})
 	require.NoError(t, err)
 
-	client := setupAuthenticatedClient(t, func(_ *http.Request) (*http.Response, error) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		if !strings.Contains(req.URL.Path, "/attach/") {
+			return attachmentFieldResponse("NonexistentField", map[string]any{"name": "file.txt"}), nil
+		}
 		return &http.Response{
 			StatusCode: http.StatusNotFound,
 			Body:       newCloseTrackingReader(errorBody),
@@ -73,28 +77,201 @@ func TestAttachmentService_Get_ErrorReturnsAPIErrorDetails(t *testing.T) {
 	assert.Equal(t, 8892, apiErr.MessageNumber)
 }
 
+// attachmentFieldResponse returns an entry response holding an attachment field value.
+func attachmentFieldResponse(field string, value any) *http.Response {
+	return newMockResponse(http.StatusOK, Entry{Values: map[string]any{field: value}})
+}
+
+// attachmentClient serves an attachment field value and the attachment
+// download response for field AttachField.
+func attachmentClient(t *testing.T, value any, download func() *http.Response) *Client {
+	t.Helper()
+
+	return setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodGet, req.Method)
+		if !strings.HasSuffix(req.URL.Path, "/attach/AttachField") {
+			assert.Equal(t, "values(AttachField)", req.URL.Query().Get("fields"))
+			return attachmentFieldResponse("AttachField", value), nil
+		}
+		return download(), nil
+	})
+}
+
+// downloadResponse returns an attachment download of data with the given
+// content type. Content-Length is unknown if contentLength is false.
+func downloadResponse(data []byte, contentType string, contentLength bool) func() *http.Response {
+	return func() *http.Response {
+		resp := &http.Response{
+			StatusCode:    http.StatusOK,
+			Body:          io.NopCloser(bytes.NewReader(data)),
+			Header:        make(http.Header),
+			ContentLength: -1,
+		}
+		if contentType != "" {
+			resp.Header.Set("Content-Type", contentType)
+		}
+		if contentLength {
+			resp.ContentLength = int64(len(data))
+		}
+		return resp
+	}
+}
+
 func TestAttachmentService_Get_Success(t *testing.T) {
 	expectedData := []byte("attachment content")
 
-	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
-		assert.Equal(t, http.MethodGet, req.Method)
-		assert.Contains(t, req.URL.Path, "/attach/")
+	client := attachmentClient(t,
+		map[string]any{"name": "notes.txt", "sizeBytes": 18},
+		downloadResponse(expectedData, "text/plain", true))
 
-		return &http.Response{
-			StatusCode: http.StatusOK,
-			Body:       io.NopCloser(bytes.NewReader(expectedData)),
-			Header:     make(http.Header),
-		}, nil
+	attachment, err := client.Attachments().Get(t.Context(), "Form", "EntryID", "AttachField")
+
+	require.NoError(t, err)
+	defer func() { _ = attachment.Close() }()
+
+	assert.Equal(t, "notes.txt", attachment.Name)
+	assert.Equal(t, int64(18), attachment.Size)
+	assert.Equal(t, "text/plain", attachment.ContentType)
+
+	data, err := io.ReadAll(attachment)
+	require.NoError(t, err)
+	assert.Equal(t, expectedData, data)
+}
+
+func TestAttachmentService_Get_ContentType(t *testing.T) {
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 600)...)
+
+	tests := []struct {
+		name        string
+		fileName    string
+		contentType string
+		want        string
+	}{
+		{"from header", "image.bin", "image/gif", "image/gif"},
+		{"from file name", "report.pdf", "", "application/pdf"},
+		{"sniffed", "screenshot", "", "image/png"},
+		{"refines octet-stream by file name", "report.pdf", "application/octet-stream", "application/pdf"},
+		{"refines octet-stream by content", "screenshot", "application/octet-stream", "image/png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := attachmentClient(t,
+				map[string]any{"name": tt.fileName},
+				downloadResponse(png, tt.contentType, false))
+
+			attachment, err := client.Attachments().Get(t.Context(), "Form", "EntryID", "AttachField")
+			require.NoError(t, err)
+			defer func() { _ = attachment.Close() }()
+
+			assert.Equal(t, tt.want, attachment.ContentType)
+			assert.Equal(t, int64(-1), attachment.Size)
+
+			// sniffing must not consume the data
+			data, err := io.ReadAll(attachment)
+			require.NoError(t, err)
+			assert.Equal(t, png, data)
+		})
+	}
+}
+
+func TestAttachmentService_Get_NoAttachment(t *testing.T) {
+	client := attachmentClient(t, nil, func() *http.Response {
+		t.Error("attachment must not be downloaded")
+		return newMockResponse(http.StatusOK, nil)
 	})
 
-	reader, err := client.Attachments().Get(t.Context(), "Form", "EntryID", "AttachField")
+	_, err := client.Attachments().Get(t.Context(), "Form", "EntryID", "AttachField")
+
+	require.ErrorIs(t, err, ErrNoAttachment)
+}
+
+func TestAttachmentService_Get_MaxSize(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), 100)
+
+	tests := []struct {
+		name     string
+		value    map[string]any
+		download func() *http.Response
+	}{
+		{"size from field", map[string]any{"name": "a.bin", "sizeBytes": 100}, func() *http.Response {
+			t.Error("attachment must not be downloaded")
+			return newMockResponse(http.StatusOK, nil)
+		}},
+		{"size from content length", map[string]any{"name": "a.bin"}, downloadResponse(data, "", true)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := attachmentClient(t, tt.value, tt.download)
+			client.maxAttachmentSize = 50
+
+			_, err := client.Attachments().Get(t.Context(), "Form", "EntryID", "AttachField")
+
+			require.ErrorIs(t, err, ErrAttachmentTooLarge)
+		})
+	}
+}
+
+func TestAttachmentService_Get_MaxSizeWhileReading(t *testing.T) {
+	client := attachmentClient(t,
+		map[string]any{"name": "a.bin"},
+		downloadResponse(bytes.Repeat([]byte("x"), 100), "", false))
+	client.maxAttachmentSize = 50
+
+	attachment, err := client.Attachments().Get(t.Context(), "Form", "EntryID", "AttachField")
+	require.NoError(t, err)
+	defer func() { _ = attachment.Close() }()
+
+	data, err := io.ReadAll(attachment)
+
+	require.ErrorIs(t, err, ErrAttachmentTooLarge)
+	assert.Len(t, data, 50)
+}
+
+func TestAttachmentService_Get_ExactMaxSize(t *testing.T) {
+	client := attachmentClient(t,
+		map[string]any{"name": "a.bin"},
+		downloadResponse(bytes.Repeat([]byte("x"), 50), "", false))
+	client.maxAttachmentSize = 50
 
+	attachment, err := client.Attachments().Get(t.Context(), "Form", "EntryID", "AttachField")
 	require.NoError(t, err)
-	defer func() { _ = reader.Close() }()
+	defer func() { _ = attachment.Close() }()
+
+	data, err := io.ReadAll(attachment)
 
-	data, err := io.ReadAll(reader)
 	require.NoError(t, err)
-	assert.Equal(t, expectedData, data)
+	assert.Len(t, data, 50)
+}
+
+func TestParseAttachmentInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    AttachmentInfo
+		wantOK  bool
+		wantErr bool
+	}{
+		{"nil", nil, AttachmentInfo{}, false, false},
+		{"with size", map[string]any{"name": "a.txt", "sizeBytes": float64(12)}, AttachmentInfo{Name: "a.txt", Size: 12}, true, false},
+		{"without size", map[string]any{"name": "a.txt"}, AttachmentInfo{Name: "a.txt", Size: -1}, true, false},
+		{"wrong type", "a.txt", AttachmentInfo{}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := ParseAttachmentInfo(tt.value)
+
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidAttachment)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
 }
 
 func TestAttachmentService_Upload_Success(t *testing.T) {
@@ -154,3 +331,91 @@ func TestAttachmentService_Upload_ErrorReturnsAPIErrorDetails(t *testing.T) {
 	require.ErrorAs(t, err, &apiErr)
 	assert.Equal(t, "File too large", apiErr.MessageText)
 }
+
+func TestAttachmentService_Upload_MaxSize(t *testing.T) {
+	t.Run("known size", func(t *testing.T) {
+		client := setupAuthenticatedClient(t, func(*http.Request) (*http.Response, error) {
+			t.Error("upload must not be sent")
+			return newMockResponse(http.StatusNoContent, nil), nil
+		})
+		client.maxAttachmentSize = 10
+
+		err := client.Attachments().Upload(t.Context(), "Form", "EntryID", "AttachField",
+			"large.bin", bytes.NewReader(make([]byte, 11)))
+
+		require.ErrorIs(t, err, ErrAttachmentTooLarge)
+	})
+
+	t.Run("unknown size", func(t *testing.T) {
+		client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+			if _, err := io.Copy(io.Discard, req.Body); err != nil {
+				return nil, err
+			}
+			return newMockResponse(http.StatusNoContent, nil), nil
+		})
+		client.maxAttachmentSize = 10
+
+		// MultiReader hides the size of the underlying reader
+		err := client.Attachments().Upload(t.Context(), "Form", "EntryID", "AttachField",
+			"large.bin", io.MultiReader(bytes.NewReader(make([]byte, 11))))
+
+		require.ErrorIs(t, err, ErrAttachmentTooLarge)
+	})
+}
+
+func TestAttachmentService_Delete(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "/api/arsys/v1/entry/Form/EntryID", req.URL.Path)
+
+		var body map[string]map[string]any
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, map[string]any{"AttachField": nil}, body["values"])
+
+		return newMockResponse(http.StatusNoContent, nil), nil
+	})
+
+	err := client.Attachments().Delete(t.Context(), "Form", "EntryID", "AttachField")
+
+	require.NoError(t, err)
+	require.ErrorIs(t, client.Attachments().Delete(t.Context(), "Form", "EntryID", ""), ErrEmptyFieldName)
+}
+
+func TestAttachmentService_List(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/api/arsys/v1/fields/Form" {
+			return newMockResponse(http.StatusOK, []Field{
+				{ID: 1, Name: "Request ID", DataType: DataTypeCharacter},
+				{ID: 2, Name: "Screenshot", DataType: DataTypeAttachment},
+				{ID: 3, Name: "Log", DataType: DataTypeAttachment},
+			}), nil
+		}
+
+		assert.Equal(t, "/api/arsys/v1/entry/Form/EntryID", req.URL.Path)
+		assert.Equal(t, "values(Screenshot,Log)", req.URL.Query().Get("fields"))
+		return newMockResponse(http.StatusOK, Entry{Values: map[string]any{
+			"Screenshot": map[string]any{"name": "screen.png", "sizeBytes": 2048},
+			"Log":        nil,
+		}}), nil
+	})
+
+	fields, err := client.Attachments().List(t.Context(), "Form", "EntryID")
+
+	require.NoError(t, err)
+	assert.Equal(t, []AttachmentField{
+		{Field: "Screenshot", AttachmentInfo: AttachmentInfo{Name: "screen.png", Size: 2048}, Attached: true},
+		{Field: "Log"},
+	}, fields)
+}
+
+func TestAttachmentService_List_NoAttachmentFields(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/api/arsys/v1/fields/Form", req.URL.Path)
+		return newMockResponse(http.StatusOK, []Field{{ID: 1, Name: "Request ID", DataType: DataTypeCharacter}}), nil
+	})
+
+	fields, err := client.Attachments().List(t.Context(), "Form", "EntryID")
+
+	require.NoError(t, err)
+	assert.Empty(t, fields)
+}
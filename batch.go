@@ -0,0 +1,238 @@
+package remedy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// BatchOption configures CreateBatch.
+type BatchOption func(*batchOptions)
+
+// batchOptions holds the configuration for batch creates.
+type batchOptions struct {
+	progress        func(BatchProgress)
+	checkpoints     CheckpointStore
+	checkpointKey   string
+	continueOnError bool
+
+	// idField is the entry ID field of the form, read from its metadata.
+	idField string
+}
+
+// BatchProgress reports the state of a running batch.
+type BatchProgress struct {
+	// Done is the number of rows processed, including resumed rows.
+	Done int
+
+	// Total is the number of input rows.
+	Total int
+
+	// Created and Failed count the rows processed by this run.
+	Created int
+	Failed  int
+}
+
+// WithProgress calls fn after each row is processed.
+// fn runs synchronously and should return quickly.
+func WithProgress(fn func(BatchProgress)) BatchOption {
+	return func(o *batchOptions) {
+		o.progress = fn
+	}
+}
+
+// WithCheckpoint records progress in store under key after each row, so
+// a later CreateBatch with the same rows and key resumes where this one
+// stopped instead of creating duplicates.
+func WithCheckpoint(store CheckpointStore, key string) BatchOption {
+	return func(o *batchOptions) {
+		o.checkpoints = store
+		o.checkpointKey = key
+	}
+}
+
+// WithContinueOnError keeps creating the remaining rows after a row fails.
+// By default CreateBatch stops at the first failure.
+func WithContinueOnError() BatchOption {
+	return func(o *batchOptions) {
+		o.continueOnError = true
+	}
+}
+
+// BatchCreated identifies an entry created from an input row.
+type BatchCreated struct {
+	Index int
+	ID    string
+}
+
+// BatchError is the failure of a single input row.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// BatchResult reports the outcome of CreateBatch.
+// Rows are identified by their index in the input slice.
+type BatchResult struct {
+	Created []BatchCreated
+	Errors  []BatchError
+
+	// Resumed is the number of leading rows skipped because a checkpoint
+	// showed they were processed by an earlier run.
+	Resumed int
+}
+
+// batchCheckpoint is the persisted state of a batch.
+type batchCheckpoint struct {
+	Next int `json:"next"`
+}
+
+// CreateBatch creates an entry for each row, in order, through the request
+// queue. A non-nil error means the batch stopped early: at the first failed
+// row (unless WithContinueOnError is set), on context cancellation, or when a
+// checkpoint could not be saved. The result is always returned and lists the
+// rows processed so far.
+//
+// If saving the checkpoint fails after a row was created, the error names
+// the row and the created ID. The row is listed in the result but not in
+// the checkpoint, so resuming from that checkpoint creates it again.
+//
+// With a checkpoint, rows before the saved position are skipped on resume,
+// including rows that failed in continue mode; their errors were reported by
+// the earlier run. Without WithContinueOnError the failed row is retried.
+func CreateBatch(ctx context.Context, entries EntryServicer, form string, rows []map[string]any, opts ...BatchOption) (*BatchResult, error) {
+	if form == "" {
+		return nil, ErrEmptyFormName
+	}
+
+	o := &batchOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	core, err := coreFieldsOf(ctx, entries, form)
+	if err != nil {
+		return nil, err
+	}
+	o.idField = core.id
+
+	start, err := o.loadCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BatchResult{Resumed: min(start, len(rows))}
+	progress := BatchProgress{Done: result.Resumed, Total: len(rows)}
+
+	for i := result.Resumed; i < len(rows); i++ {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		if err := createBatchRow(ctx, entries, form, i, rows[i], o, result, &progress); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// createBatchRow creates a single row and records its outcome.
+// It returns an error if the batch must stop.
+func createBatchRow(ctx context.Context, entries EntryServicer, form string, i int, row map[string]any, o *batchOptions, result *BatchResult, progress *BatchProgress) error {
+	entry, err := entries.Create(ctx, form, row)
+	if err != nil {
+		result.Errors = append(result.Errors, BatchError{Index: i, Err: err})
+		progress.Failed++
+
+		if !o.continueOnError {
+			o.report(*progress)
+			return &BatchError{Index: i, Err: err}
+		}
+	} else {
+		result.Created = append(result.Created, BatchCreated{Index: i, ID: createdID(entry, o.idField)})
+		progress.Created++
+	}
+
+	progress.Done++
+	if err := o.saveCheckpoint(ctx, i+1); err != nil {
+		if entry != nil {
+			return fmt.Errorf("row %d created as %s but not checkpointed, a resumed batch creates it again: %w",
+				i, createdID(entry, o.idField), err)
+		}
+		return err
+	}
+	o.report(*progress)
+
+	return nil
+}
+
+// report calls the progress callback if set.
+func (o *batchOptions) report(p BatchProgress) {
+	if o.progress != nil {
+		o.progress(p)
+	}
+}
+
+// loadCheckpoint returns the index of the first row to process.
+func (o *batchOptions) loadCheckpoint(ctx context.Context) (int, error) {
+	if o.checkpoints == nil {
+		return 0, nil
+	}
+
+	data, err := o.checkpoints.Load(ctx, o.checkpointKey)
+	if errors.Is(err, ErrNoCheckpoint) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("loading batch checkpoint: %w", err)
+	}
+
+	var cp batchCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return 0, fmt.Errorf("decoding batch checkpoint: %w", err)
+	}
+
+	return cp.Next, nil
+}
+
+// saveCheckpoint records that rows before next have been processed.
+func (o *batchOptions) saveCheckpoint(ctx context.Context, next int) error {
+	if o.checkpoints == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(batchCheckpoint{Next: next})
+	if err != nil {
+		return fmt.Errorf("encoding batch checkpoint: %w", err)
+	}
+
+	if err := o.checkpoints.Save(ctx, o.checkpointKey, data); err != nil {
+		return fmt.Errorf("saving batch checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// createdID returns the ID of an entry returned by Create, which is either
+// the ID field in the response body or the ID taken from the Location header.
+func createdID(e *Entry, idField string) string {
+	if id := e.id(idField); id != "" {
+		return id
+	}
+
+	id, _ := e.Values["Entry_id"].(string)
+
+	return id
+}
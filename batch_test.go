@@ -0,0 +1,131 @@
+package remedy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchCreateHandler creates entries with sequential IDs and rejects rows
+// whose Summary is "bad".
+func batchCreateHandler(t *testing.T, created *[]string) func(*http.Request) (*http.Response, error) {
+	t.Helper()
+
+	return func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, req.Method)
+
+		var body map[string]map[string]any
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+
+		summary, _ := body["values"]["Summary"].(string)
+		if summary == "bad" {
+			return newMockResponse(http.StatusBadRequest, []apiErrorResponse{
+				{MessageType: "ERROR", MessageText: "Invalid value", MessageNumber: 306},
+			}), nil
+		}
+
+		*created = append(*created, summary)
+		id := fmt.Sprintf("REQ%d", len(*created))
+
+		return newMockResponse(http.StatusCreated, Entry{Values: map[string]any{"Request ID": id}}), nil
+	}
+}
+
+func TestEntryService_CreateBatch_ContinueOnError(t *testing.T) {
+	var created []string
+	client := setupFormClient(t, nil, batchCreateHandler(t, &created))
+
+	rows := []map[string]any{{"Summary": "a"}, {"Summary": "bad"}, {"Summary": "c"}}
+
+	var progress []BatchProgress
+	result, err := CreateBatch(t.Context(), client.Entries(), "Form", rows,
+		WithContinueOnError(),
+		WithProgress(func(p BatchProgress) { progress = append(progress, p) }),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, []BatchCreated{{Index: 0, ID: "REQ1"}, {Index: 2, ID: "REQ2"}}, result.Created)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, 1, result.Errors[0].Index)
+
+	var apiErr *APIError
+	require.ErrorAs(t, result.Errors[0].Err, &apiErr)
+	assert.Equal(t, 306, apiErr.MessageNumber)
+
+	require.Len(t, progress, 3)
+	assert.Equal(t, BatchProgress{Done: 3, Total: 3, Created: 2, Failed: 1}, progress[2])
+}
+
+func TestEntryService_CreateBatch_StopsOnFirstError(t *testing.T) {
+	var created []string
+	client := setupFormClient(t, nil, batchCreateHandler(t, &created))
+
+	rows := []map[string]any{{"Summary": "a"}, {"Summary": "bad"}, {"Summary": "c"}}
+
+	result, err := CreateBatch(t.Context(), client.Entries(), "Form", rows)
+
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, 1, batchErr.Index)
+	assert.Equal(t, []string{"a"}, created, "rows after the failure must not be created")
+	assert.Len(t, result.Created, 1)
+}
+
+func TestEntryService_CreateBatch_ResumesFromCheckpoint(t *testing.T) {
+	store := NewMemoryCheckpointStore()
+	rows := []map[string]any{{"Summary": "a"}, {"Summary": "bad"}, {"Summary": "c"}}
+
+	var created []string
+	client := setupFormClient(t, nil, batchCreateHandler(t, &created))
+
+	_, err := CreateBatch(t.Context(), client.Entries(), "Form", rows, WithCheckpoint(store, "import"))
+	require.Error(t, err)
+
+	// Fix the failing row and run again: only the remaining rows are created
+	rows[1]["Summary"] = "b"
+	result, err := CreateBatch(t.Context(), client.Entries(), "Form", rows, WithCheckpoint(store, "import"))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Resumed)
+	assert.Equal(t, []string{"a", "b", "c"}, created)
+	assert.Equal(t, []int{1, 2}, []int{result.Created[0].Index, result.Created[1].Index})
+}
+
+// failingSaveStore is a checkpoint store whose saves fail.
+type failingSaveStore struct {
+	*MemoryCheckpointStore
+}
+
+var errSaveFailed = errors.New("disk full")
+
+func (failingSaveStore) Save(context.Context, string, []byte) error {
+	return errSaveFailed
+}
+
+func TestEntryService_CreateBatch_CheckpointFailureNamesCreatedRow(t *testing.T) {
+	var created []string
+	client := setupFormClient(t, nil, batchCreateHandler(t, &created))
+
+	result, err := CreateBatch(t.Context(), client.Entries(), "Form",
+		[]map[string]any{{"Summary": "a"}, {"Summary": "b"}},
+		WithCheckpoint(failingSaveStore{NewMemoryCheckpointStore()}, "batch"))
+
+	require.ErrorIs(t, err, errSaveFailed)
+	assert.Contains(t, err.Error(), "row 0 created as REQ1")
+	assert.Equal(t, []BatchCreated{{Index: 0, ID: "REQ1"}}, result.Created)
+	assert.Equal(t, []string{"a"}, created)
+}
+
+func TestEntryService_CreateBatch_EmptyFormReturnsError(t *testing.T) {
+	client := New("https://remedy.example.com")
+
+	_, err := CreateBatch(t.Context(), client.Entries(), "", []map[string]any{{"Summary": "a"}})
+
+	assert.ErrorIs(t, err, ErrEmptyFormName)
+}
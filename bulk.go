@@ -0,0 +1,264 @@
+package remedy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// defaultScanPageSize is the page size used when scanning entry IDs.
+const defaultScanPageSize = 1000
+
+// ErrEmptyQualification indicates a bulk operation was called without a
+// qualification. Use an explicit qualification such as "1=1" to target
+// every entry of a form.
+var ErrEmptyQualification = errors.New("remedy: qualification cannot be empty")
+
+// BulkOption configures UpdateWhere and DeleteWhere.
+type BulkOption func(*bulkOptions)
+
+// bulkOptions holds the configuration for bulk operations.
+type bulkOptions struct {
+	idField      string
+	pageSize     int
+	dryRun       bool
+	sessions     []EntryServicer
+	deleteOption DeleteOption
+}
+
+// WithIDField sets the field holding the entry ID. The default is the
+// name of field 1 in the form metadata, such as Request ID or Entry ID.
+func WithIDField(field string) BulkOption {
+	return func(o *bulkOptions) {
+		o.idField = field
+	}
+}
+
+// WithScanPageSize sets how many IDs are fetched per request while
+// scanning for matching entries. The default is 1000.
+func WithScanPageSize(n int) BulkOption {
+	return func(o *bulkOptions) {
+		o.pageSize = n
+	}
+}
+
+// WithDryRun only counts the matching entries without modifying them.
+func WithDryRun() BulkOption {
+	return func(o *bulkOptions) {
+		o.dryRun = true
+	}
+}
+
+// WithSessions spreads the per-entry requests across additional
+// authenticated sessions, such as the entry services of other clients.
+// Each client serializes its own requests, so the number of concurrent
+// requests equals the number of sessions including the calling client.
+func WithSessions(sessions ...EntryServicer) BulkOption {
+	return func(o *bulkOptions) {
+		o.sessions = sessions
+	}
+}
+
+// WithBulkDeleteOption sets the delete option used by DeleteWhere.
+func WithBulkDeleteOption(opt DeleteOption) BulkOption {
+	return func(o *bulkOptions) {
+		o.deleteOption = opt
+	}
+}
+
+// buildBulkOptions applies bulk options over the defaults.
+func buildBulkOptions(opts []BulkOption) *bulkOptions {
+	o := &bulkOptions{
+		pageSize: defaultScanPageSize,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// BulkResult is the outcome of a bulk operation on a single entry.
+type BulkResult struct {
+	ID string
+
+	// Err is nil on success. Server rejections are *APIError values.
+	Err error
+}
+
+// BulkReport summarizes a bulk operation.
+type BulkReport struct {
+	// Matched is the number of entries matching the qualification.
+	Matched int
+
+	// DryRun reports whether the operation only counted entries.
+	DryRun bool
+
+	// Results holds one result per matched entry, in ID order.
+	// It is empty for dry runs.
+	Results []BulkResult
+}
+
+// Succeeded returns the IDs of the entries that were processed successfully.
+func (r *BulkReport) Succeeded() []string {
+	var ids []string
+	for _, res := range r.Results {
+		if res.Err == nil {
+			ids = append(ids, res.ID)
+		}
+	}
+
+	return ids
+}
+
+// Failed returns the results of the entries that could not be processed.
+func (r *BulkReport) Failed() []BulkResult {
+	var failed []BulkResult
+	for _, res := range r.Results {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+
+	return failed
+}
+
+// UpdateWhere applies values to every entry matching the qualification.
+// Matching IDs are collected before any update, so updates that change
+// whether an entry matches do not affect which entries are processed.
+// Per-entry failures are reported in the BulkReport; the returned error is
+// non-nil only if the matching entries could not be determined.
+func UpdateWhere(ctx context.Context, entries EntryServicer, form, qualification string, values map[string]any, opts ...BulkOption) (*BulkReport, error) {
+	return bulk(ctx, entries, form, qualification, buildBulkOptions(opts), func(ctx context.Context, svc EntryServicer, id string) error {
+		return svc.Update(ctx, form, id, values)
+	})
+}
+
+// DeleteWhere deletes every entry matching the qualification.
+// See UpdateWhere for how entries are matched and results reported.
+func DeleteWhere(ctx context.Context, entries EntryServicer, form, qualification string, opts ...BulkOption) (*BulkReport, error) {
+	o := buildBulkOptions(opts)
+
+	var deleteOpts []DeleteOption
+	if o.deleteOption != "" {
+		deleteOpts = append(deleteOpts, o.deleteOption)
+	}
+
+	return bulk(ctx, entries, form, qualification, o, func(ctx context.Context, svc EntryServicer, id string) error {
+		return svc.Delete(ctx, form, id, deleteOpts...)
+	})
+}
+
+// bulk collects the IDs matching qualification and applies fn to each.
+func bulk(ctx context.Context, entries EntryServicer, form, qualification string, o *bulkOptions, fn bulkFunc) (*BulkReport, error) {
+	if form == "" {
+		return nil, ErrEmptyFormName
+	}
+	if qualification == "" {
+		return nil, ErrEmptyQualification
+	}
+
+	idField, err := idFieldOf(ctx, entries, form, o.idField)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	err = scanIDs(ctx, entries, form, qualification, idField, o.pageSize, func(page []string) error {
+		ids = append(ids, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("finding matching entries: %w", err)
+	}
+
+	report := &BulkReport{Matched: len(ids), DryRun: o.dryRun}
+	if o.dryRun {
+		return report, nil
+	}
+
+	sessions := append([]EntryServicer{entries}, o.sessions...)
+	report.Results = runBulk(ctx, sessions, ids, fn)
+
+	return report, nil
+}
+
+// bulkFunc performs a bulk operation on one entry using the given session.
+type bulkFunc func(ctx context.Context, svc EntryServicer, id string) error
+
+// runBulk applies fn to every ID, with one worker per session.
+// Entries not processed because ctx was cancelled report the context error.
+func runBulk(ctx context.Context, sessions []EntryServicer, ids []string, fn bulkFunc) []BulkResult {
+	results := make([]BulkResult, len(ids))
+	for i, id := range ids {
+		results[i].ID = id
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+
+	for _, svc := range sessions {
+		wg.Go(func() {
+			for i := range next {
+				results[i].Err = fn(ctx, svc, ids[i])
+			}
+		})
+	}
+
+	for i := range ids {
+		if ctx.Err() != nil {
+			results[i].Err = ctx.Err()
+			continue
+		}
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	return results
+}
+
+// idFieldOf returns field, or the name of the entry ID field of form if
+// field is empty.
+func idFieldOf(ctx context.Context, entries EntryServicer, form, field string) (string, error) {
+	if field != "" {
+		return field, nil
+	}
+
+	core, err := coreFieldsOf(ctx, entries, form)
+	if err != nil {
+		return "", err
+	}
+
+	return core.id, nil
+}
+
+// scanIDs pages through the IDs of entries matching qualification with
+// keyset pagination on idField, calling fn with each page of IDs.
+func scanIDs(ctx context.Context, entries EntryServicer, form, qualification, idField string, pageSize int, fn func(ids []string) error) error {
+	pages := KeysetPages(ctx, entries, form, idField,
+		WithQualification(qualification),
+		WithFields(idField),
+		WithLimit(pageSize),
+	)
+
+	for page, err := range pages {
+		if err != nil {
+			return err
+		}
+
+		ids := make([]string, 0, len(page))
+		for i := range page {
+			if id := page[i].id(idField); id != "" {
+				ids = append(ids, id)
+			}
+		}
+
+		if err := fn(ids); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
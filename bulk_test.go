@@ -0,0 +1,174 @@
+package remedy
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// idListResponse serves a page of an ID-only List request from sorted ids,
+// honoring the limit parameter and the keyset condition on Request ID.
+func idListResponse(t *testing.T, req *http.Request, ids []string) *http.Response {
+	t.Helper()
+
+	query := req.URL.Query()
+	assert.Equal(t, "values(Request ID)", query.Get("fields"))
+
+	limit, err := strconv.Atoi(query.Get("limit"))
+	require.NoError(t, err)
+
+	start := 0
+	if _, after, ok := strings.Cut(query.Get("q"), `'Request ID' > `); ok {
+		last, err := strconv.Unquote(after)
+		require.NoError(t, err)
+		start, _ = slices.BinarySearch(ids, last)
+		if start < len(ids) && ids[start] == last {
+			start++
+		}
+	}
+
+	end := min(start+limit, len(ids))
+	list := EntryList{}
+	for _, id := range ids[start:end] {
+		list.Entries = append(list.Entries, Entry{Values: map[string]any{"Request ID": id}})
+	}
+
+	return newMockResponse(http.StatusOK, list)
+}
+
+func TestEntryService_UpdateWhere(t *testing.T) {
+	ids := []string{"REQ1", "REQ2", "REQ3"}
+	var updated []string
+
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet {
+			assert.True(t, strings.HasPrefix(req.URL.Query().Get("q"), `('Status' = "New")`) ||
+				req.URL.Query().Get("q") == `'Status' = "New"`)
+			return idListResponse(t, req, ids), nil
+		}
+
+		assert.Equal(t, http.MethodPut, req.Method)
+		id := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+		if id == "REQ2" {
+			return newMockResponse(http.StatusBadRequest, []apiErrorResponse{
+				{MessageType: "ERROR", MessageText: "Required field missing", MessageNumber: 326},
+			}), nil
+		}
+		updated = append(updated, id)
+
+		return newMockResponse(http.StatusNoContent, nil), nil
+	})
+
+	report, err := UpdateWhere(t.Context(), client.Entries(), "Form", `'Status' = "New"`,
+		map[string]any{"Status": "Assigned"}, WithScanPageSize(2))
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Matched)
+	assert.Equal(t, []string{"REQ1", "REQ3"}, updated)
+	assert.Equal(t, []string{"REQ1", "REQ3"}, report.Succeeded())
+
+	failed := report.Failed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "REQ2", failed[0].ID)
+
+	var apiErr *APIError
+	require.ErrorAs(t, failed[0].Err, &apiErr)
+	assert.Equal(t, 326, apiErr.MessageNumber)
+}
+
+func TestEntryService_UpdateWhere_DryRun(t *testing.T) {
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodGet, req.Method, "dry run must not modify entries")
+		return idListResponse(t, req, []string{"REQ1", "REQ2"}), nil
+	})
+
+	report, err := UpdateWhere(t.Context(), client.Entries(), "Form", "1=1",
+		map[string]any{"Status": "Closed"}, WithDryRun())
+
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 2, report.Matched)
+	assert.Empty(t, report.Results)
+}
+
+func TestEntryService_DeleteWhere_WithSessions(t *testing.T) {
+	ids := []string{"REQ1", "REQ2", "REQ3", "REQ4", "REQ5", "REQ6"}
+
+	var mu sync.Mutex
+	deleted := map[string]int{}
+	deleteHandler := func(session int) func(*http.Request) (*http.Response, error) {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodGet {
+				return idListResponse(t, req, ids), nil
+			}
+
+			assert.Equal(t, http.MethodDelete, req.Method)
+			assert.Equal(t, "options=FORCE", req.URL.RawQuery)
+
+			mu.Lock()
+			deleted[req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]] = session
+			mu.Unlock()
+
+			return newMockResponse(http.StatusNoContent, nil), nil
+		}
+	}
+
+	primary := setupFormClient(t, nil, deleteHandler(1))
+	secondary := setupFormClient(t, nil, deleteHandler(2))
+
+	report, err := DeleteWhere(t.Context(), primary.Entries(), "Form", "1=1",
+		WithSessions(secondary.Entries()),
+		WithBulkDeleteOption(DeleteOptionForce),
+	)
+
+	require.NoError(t, err)
+	assert.Len(t, report.Succeeded(), len(ids))
+	assert.Len(t, deleted, len(ids))
+}
+
+func TestRunBulk_ContextCancelled(t *testing.T) {
+	client := New("https://remedy.example.com")
+
+	report := &BulkReport{Results: runBulk(canceledContext(t), []EntryServicer{client.Entries()}, []string{"REQ1"},
+		func(_ context.Context, _ EntryServicer, _ string) error {
+			t.Error("no entries should be processed after cancellation")
+			return nil
+		})}
+
+	require.Len(t, report.Failed(), 1)
+	assert.ErrorIs(t, report.Failed()[0].Err, context.Canceled)
+}
+
+func TestEntryService_UpdateWhere_EmptyQualificationReturnsError(t *testing.T) {
+	client := New("https://remedy.example.com")
+
+	_, err := UpdateWhere(t.Context(), client.Entries(), "Form", "", map[string]any{"Status": "Closed"})
+	require.ErrorIs(t, err, ErrEmptyQualification)
+
+	_, err = DeleteWhere(t.Context(), client.Entries(), "", "1=1")
+	assert.ErrorIs(t, err, ErrEmptyFormName)
+}
+
+func TestEntry_ID_FromSelfLink(t *testing.T) {
+	entry := Entry{Links: []Link{
+		{Rel: "self", Href: "https://remedy.example.com/api/arsys/v1/entry/Form/REQ42"},
+	}}
+
+	assert.Equal(t, "REQ42", entry.id("Request ID"))
+}
+
+func canceledContext(t *testing.T) context.Context {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	return ctx
+}
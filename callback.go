@@ -0,0 +1,364 @@
+package remedy
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCallbackSecretHeader carries the shared secret of a callback.
+	DefaultCallbackSecretHeader = "X-Remedy-Secret"
+
+	// CallbackSignatureHeader carries the HMAC signature of a callback body
+	// as "sha256=" followed by the hex-encoded HMAC-SHA256 of the body.
+	CallbackSignatureHeader = "X-Remedy-Signature"
+
+	// defaultCallbackMaxBody limits the size of callback bodies.
+	defaultCallbackMaxBody = 1 << 20
+
+	// defaultCallbackTolerance is the maximum age of an accepted callback.
+	defaultCallbackTolerance = 5 * time.Minute
+
+	// signaturePrefix precedes the hex signature in CallbackSignatureHeader.
+	signaturePrefix = "sha256="
+)
+
+// Errors reported by CallbackHandler.
+var (
+	// ErrCallbackUnauthenticated indicates a callback with a missing or wrong
+	// secret or signature.
+	ErrCallbackUnauthenticated = errors.New("remedy: callback not authenticated")
+
+	// ErrCallbackReplayed indicates a callback that was already processed or
+	// whose timestamp is outside the accepted window.
+	ErrCallbackReplayed = errors.New("remedy: callback replayed")
+
+	// ErrInvalidCallback indicates a callback body that cannot be decoded.
+	ErrInvalidCallback = errors.New("remedy: invalid callback")
+)
+
+// CallbackEvent is a change notification sent by an AR filter or
+// escalation. The JSON body of a callback has the form:
+//
+//	{
+//	  "eventId":   "optional unique ID of the notification",
+//	  "form":      "HPD:Help Desk",
+//	  "entryId":   "INC000000000123",
+//	  "action":    "update",
+//	  "timestamp": 1718000000,
+//	  "values":    {"Status": "Assigned", "Assignee": "Jane Doe"}
+//	}
+//
+// The timestamp is required and may be epoch seconds or an AR timestamp,
+// such as $TIMESTAMP$ in a Set Fields action. If eventId is missing, the
+// SHA-256 of the body identifies the event for replay protection.
+type CallbackEvent struct {
+	EventID   string
+	Form      string
+	EntryID   string
+	Action    string
+	Timestamp time.Time
+	Entry     Entry
+}
+
+// callbackPayload is the wire format of a callback.
+type callbackPayload struct {
+	EventID   string         `json:"eventId"`
+	Form      string         `json:"form"`
+	EntryID   string         `json:"entryId"`
+	Action    string         `json:"action"`
+	Timestamp any            `json:"timestamp"`
+	Values    map[string]any `json:"values"`
+}
+
+// CallbackFunc processes a callback event. Returning an error responds
+// with HTTP 500 so the sender can retry; the event is then not recorded
+// for replay protection. The error text is not sent to the sender; use
+// WithCallbackErrorHandler to log it.
+type CallbackFunc func(ctx context.Context, event *CallbackEvent) error
+
+// CallbackOption configures a CallbackHandler.
+type CallbackOption func(*CallbackHandler)
+
+// WithCallbackSecret authenticates callbacks by a shared secret sent in
+// the given header, or DefaultCallbackSecretHeader if header is empty.
+func WithCallbackSecret(header, secret string) CallbackOption {
+	return func(h *CallbackHandler) {
+		if header == "" {
+			header = DefaultCallbackSecretHeader
+		}
+		h.secretHeader = header
+		h.secret = secret
+	}
+}
+
+// WithCallbackHMAC authenticates callbacks by an HMAC-SHA256 signature of
+// the body in CallbackSignatureHeader, computed with key.
+func WithCallbackHMAC(key []byte) CallbackOption {
+	return func(h *CallbackHandler) {
+		h.hmacKey = key
+	}
+}
+
+// WithCallbackTolerance sets how old, or how far in the future, a callback
+// timestamp may be. Event IDs are remembered for the same window to reject
+// replays. The default is five minutes.
+func WithCallbackTolerance(d time.Duration) CallbackOption {
+	return func(h *CallbackHandler) {
+		h.tolerance = d
+	}
+}
+
+// WithCallbackMaxBody limits the size of callback bodies. The default is 1 MiB.
+func WithCallbackMaxBody(n int64) CallbackOption {
+	return func(h *CallbackHandler) {
+		h.maxBody = n
+	}
+}
+
+// WithCallbackErrorHandler sets a function called with the error returned
+// by a form handler. The response to the sender only carries a generic
+// message, so this is where handler failures are logged.
+func WithCallbackErrorHandler(fn func(r *http.Request, event *CallbackEvent, err error)) CallbackOption {
+	return func(h *CallbackHandler) {
+		h.onError = fn
+	}
+}
+
+// CallbackHandler is an http.Handler that receives change notifications
+// sent by AR workflow, authenticates them and dispatches them to the
+// handler registered for their form.
+//
+// Example usage:
+//
+//	callbacks := remedy.NewCallbackHandler(remedy.WithCallbackHMAC(key))
+//	callbacks.Handle("HPD:Help Desk", func(ctx context.Context, e *remedy.CallbackEvent) error {
+//	    log.Printf("%s %s: %v", e.Action, e.EntryID, e.Entry.Values["Status"])
+//	    return nil
+//	})
+//	http.Handle("/remedy/callback", callbacks)
+//
+// Responses are 204 on success, 401 for failed authentication, 400 for
+// invalid bodies, 404 for forms without a handler, 409 for replays and
+// 500 when the form handler fails. At least one of WithCallbackSecret and
+// WithCallbackHMAC must be set; otherwise every callback is rejected.
+type CallbackHandler struct {
+	secretHeader string
+	secret       string
+	hmacKey      []byte
+	tolerance    time.Duration
+	maxBody      int64
+	onError      func(*http.Request, *CallbackEvent, error)
+
+	handlers   map[string]CallbackFunc
+	handlersMu sync.RWMutex
+
+	// seen maps processed event IDs to their timestamps.
+	seen   map[string]time.Time
+	seenMu sync.Mutex
+
+	now func() time.Time
+}
+
+// NewCallbackHandler creates a callback handler.
+func NewCallbackHandler(opts ...CallbackOption) *CallbackHandler {
+	h := &CallbackHandler{
+		tolerance: defaultCallbackTolerance,
+		maxBody:   defaultCallbackMaxBody,
+		handlers:  make(map[string]CallbackFunc),
+		seen:      make(map[string]time.Time),
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Handle registers fn for callbacks about form. The empty form name
+// registers a handler for forms without their own handler.
+func (h *CallbackHandler) Handle(form string, fn CallbackFunc) {
+	h.handlersMu.Lock()
+	defer h.handlersMu.Unlock()
+
+	h.handlers[form] = fn
+}
+
+// ServeHTTP implements http.Handler.
+func (h *CallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBody))
+	if err != nil {
+		status := http.StatusBadRequest
+		if _, ok := errors.AsType[*http.MaxBytesError](err); ok {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, "reading body: "+err.Error(), status)
+		return
+	}
+
+	if err := h.authenticate(r, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	event, err := decodeCallback(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fn, ok := h.handler(event.Form)
+	if !ok {
+		http.Error(w, "no handler for form "+event.Form, http.StatusNotFound)
+		return
+	}
+
+	if err := h.checkReplay(event); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	if err := fn(r.Context(), event); err != nil {
+		h.forget(event.EventID)
+		if h.onError != nil {
+			h.onError(r, event, err)
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authenticate verifies the shared secret and the HMAC signature, if configured.
+func (h *CallbackHandler) authenticate(r *http.Request, body []byte) error {
+	if h.secret == "" && len(h.hmacKey) == 0 {
+		return fmt.Errorf("%w: no authentication configured", ErrCallbackUnauthenticated)
+	}
+
+	if h.secret != "" {
+		got := r.Header.Get(h.secretHeader)
+		if subtle.ConstantTimeCompare([]byte(got), []byte(h.secret)) != 1 {
+			return fmt.Errorf("%w: wrong secret", ErrCallbackUnauthenticated)
+		}
+	}
+
+	if len(h.hmacKey) > 0 {
+		got, err := hex.DecodeString(strings.TrimPrefix(r.Header.Get(CallbackSignatureHeader), signaturePrefix))
+		if err != nil || !hmac.Equal(got, SignCallback(h.hmacKey, body)) {
+			return fmt.Errorf("%w: wrong signature", ErrCallbackUnauthenticated)
+		}
+	}
+
+	return nil
+}
+
+// handler returns the handler for form, or the default handler.
+func (h *CallbackHandler) handler(form string) (CallbackFunc, bool) {
+	h.handlersMu.RLock()
+	defer h.handlersMu.RUnlock()
+
+	if fn, ok := h.handlers[form]; ok {
+		return fn, true
+	}
+	fn, ok := h.handlers[""]
+
+	return fn, ok
+}
+
+// checkReplay rejects events outside the tolerance window and events
+// already seen, and records the event.
+func (h *CallbackHandler) checkReplay(event *CallbackEvent) error {
+	now := h.now()
+	if age := now.Sub(event.Timestamp); age > h.tolerance || age < -h.tolerance {
+		return fmt.Errorf("%w: timestamp %s outside accepted window", ErrCallbackReplayed,
+			event.Timestamp.Format(time.RFC3339))
+	}
+
+	h.seenMu.Lock()
+	defer h.seenMu.Unlock()
+
+	for id, ts := range h.seen {
+		if now.Sub(ts) > h.tolerance {
+			delete(h.seen, id)
+		}
+	}
+
+	if _, ok := h.seen[event.EventID]; ok {
+		return fmt.Errorf("%w: event %s already processed", ErrCallbackReplayed, event.EventID)
+	}
+	h.seen[event.EventID] = event.Timestamp
+
+	return nil
+}
+
+// forget removes an event from the replay window so it can be retried.
+func (h *CallbackHandler) forget(eventID string) {
+	h.seenMu.Lock()
+	defer h.seenMu.Unlock()
+
+	delete(h.seen, eventID)
+}
+
+// decodeCallback decodes a callback body.
+func decodeCallback(body []byte) (*CallbackEvent, error) {
+	var payload callbackPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCallback, err)
+	}
+
+	if payload.Form == "" {
+		return nil, fmt.Errorf("%w: missing form", ErrInvalidCallback)
+	}
+	if payload.Timestamp == nil {
+		return nil, fmt.Errorf("%w: missing timestamp", ErrInvalidCallback)
+	}
+
+	timestamp, err := parseTimeValue(payload.Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCallback, err)
+	}
+
+	eventID := payload.EventID
+	if eventID == "" {
+		sum := sha256.Sum256(body)
+		eventID = hex.EncodeToString(sum[:])
+	}
+
+	return &CallbackEvent{
+		EventID:   eventID,
+		Form:      payload.Form,
+		EntryID:   payload.EntryID,
+		Action:    payload.Action,
+		Timestamp: timestamp,
+		Entry:     Entry{Values: payload.Values},
+	}, nil
+}
+
+// SignCallback returns the HMAC-SHA256 of body with key, as expected in
+// CallbackSignatureHeader after hex encoding and the "sha256=" prefix.
+// It is useful for testing callback senders.
+func SignCallback(key, body []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+
+	return mac.Sum(nil)
+}
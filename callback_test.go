@@ -0,0 +1,228 @@
+package remedy
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var callbackNow = time.Date(2024, 6, 10, 6, 13, 20, 0, time.UTC)
+
+// newTestCallbackHandler creates a handler with a fixed clock.
+func newTestCallbackHandler(opts ...CallbackOption) *CallbackHandler {
+	h := NewCallbackHandler(opts...)
+	h.now = func() time.Time { return callbackNow }
+	return h
+}
+
+// postCallback sends body to h with the given headers and returns the status.
+func postCallback(h http.Handler, body string, headers map[string]string) int {
+	req := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(body))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec.Code
+}
+
+// signedHeaders returns the signature header for body.
+func signedHeaders(key []byte, body string) map[string]string {
+	return map[string]string{
+		CallbackSignatureHeader: "sha256=" + hex.EncodeToString(SignCallback(key, []byte(body))),
+	}
+}
+
+const testCallbackBody = `{
+	"eventId": "evt-1",
+	"form": "HPD:Help Desk",
+	"entryId": "INC000000000123",
+	"action": "update",
+	"timestamp": 1718000000,
+	"values": {"Status": "Assigned"}
+}`
+
+func TestCallbackHandler_HMAC(t *testing.T) {
+	key := []byte("s3cret")
+	h := newTestCallbackHandler(WithCallbackHMAC(key))
+
+	var got *CallbackEvent
+	h.Handle("HPD:Help Desk", func(_ context.Context, e *CallbackEvent) error {
+		got = e
+		return nil
+	})
+
+	status := postCallback(h, testCallbackBody, signedHeaders(key, testCallbackBody))
+
+	require.Equal(t, http.StatusNoContent, status)
+	require.NotNil(t, got)
+	assert.Equal(t, "evt-1", got.EventID)
+	assert.Equal(t, "INC000000000123", got.EntryID)
+	assert.Equal(t, "update", got.Action)
+	assert.Equal(t, callbackNow, got.Timestamp)
+	assert.Equal(t, "Assigned", got.Entry.Values["Status"])
+}
+
+func TestCallbackHandler_Authentication(t *testing.T) {
+	key := []byte("s3cret")
+
+	tests := []struct {
+		name    string
+		opts    []CallbackOption
+		headers map[string]string
+		want    int
+	}{
+		{"valid secret", []CallbackOption{WithCallbackSecret("", "token")},
+			map[string]string{DefaultCallbackSecretHeader: "token"}, http.StatusNoContent},
+		{"custom secret header", []CallbackOption{WithCallbackSecret("X-Token", "token")},
+			map[string]string{"X-Token": "token"}, http.StatusNoContent},
+		{"wrong secret", []CallbackOption{WithCallbackSecret("", "token")},
+			map[string]string{DefaultCallbackSecretHeader: "guess"}, http.StatusUnauthorized},
+		{"missing signature", []CallbackOption{WithCallbackHMAC(key)},
+			nil, http.StatusUnauthorized},
+		{"signature with other key", []CallbackOption{WithCallbackHMAC(key)},
+			signedHeaders([]byte("other"), testCallbackBody), http.StatusUnauthorized},
+		{"no authentication configured", nil,
+			nil, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestCallbackHandler(tt.opts...)
+			h.Handle("HPD:Help Desk", func(context.Context, *CallbackEvent) error { return nil })
+
+			assert.Equal(t, tt.want, postCallback(h, testCallbackBody, tt.headers))
+		})
+	}
+}
+
+func TestCallbackHandler_ReplayProtection(t *testing.T) {
+	headers := map[string]string{DefaultCallbackSecretHeader: "token"}
+	h := newTestCallbackHandler(WithCallbackSecret("", "token"))
+
+	calls := 0
+	h.Handle("HPD:Help Desk", func(context.Context, *CallbackEvent) error {
+		calls++
+		return nil
+	})
+
+	assert.Equal(t, http.StatusNoContent, postCallback(h, testCallbackBody, headers))
+	assert.Equal(t, http.StatusConflict, postCallback(h, testCallbackBody, headers))
+	assert.Equal(t, 1, calls)
+
+	stale := strings.Replace(testCallbackBody, `"evt-1"`, `"evt-2"`, 1)
+	stale = strings.Replace(stale, "1718000000", "1717990000", 1)
+	assert.Equal(t, http.StatusConflict, postCallback(h, stale, headers))
+	assert.Equal(t, 1, calls)
+}
+
+func TestCallbackHandler_FailedHandlerCanBeRetried(t *testing.T) {
+	headers := map[string]string{DefaultCallbackSecretHeader: "token"}
+	h := newTestCallbackHandler(WithCallbackSecret("", "token"))
+
+	fail := true
+	h.Handle("HPD:Help Desk", func(context.Context, *CallbackEvent) error {
+		if fail {
+			fail = false
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, postCallback(h, testCallbackBody, headers))
+	assert.Equal(t, http.StatusNoContent, postCallback(h, testCallbackBody, headers))
+}
+
+func TestCallbackHandler_HidesHandlerError(t *testing.T) {
+	var logged error
+	h := newTestCallbackHandler(
+		WithCallbackSecret("", "token"),
+		WithCallbackErrorHandler(func(_ *http.Request, e *CallbackEvent, err error) {
+			assert.Equal(t, "INC000000000123", e.EntryID)
+			logged = err
+		}),
+	)
+	h.Handle("HPD:Help Desk", func(context.Context, *CallbackEvent) error {
+		return errors.New("dial tcp 10.0.0.5:5432: connection refused")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(testCallbackBody))
+	req.Header.Set(DefaultCallbackSecretHeader, "token")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "10.0.0.5")
+	require.Error(t, logged)
+	assert.Contains(t, logged.Error(), "connection refused")
+}
+
+func TestCallbackHandler_Dispatch(t *testing.T) {
+	headers := map[string]string{DefaultCallbackSecretHeader: "token"}
+	h := newTestCallbackHandler(WithCallbackSecret("", "token"))
+
+	var forms []string
+	h.Handle("CHG:Infrastructure Change", func(_ context.Context, e *CallbackEvent) error {
+		forms = append(forms, "change:"+e.Form)
+		return nil
+	})
+
+	// without a default handler, unknown forms are rejected
+	assert.Equal(t, http.StatusNotFound, postCallback(h, testCallbackBody, headers))
+
+	h.Handle("", func(_ context.Context, e *CallbackEvent) error {
+		forms = append(forms, "default:"+e.Form)
+		return nil
+	})
+	change := strings.Replace(testCallbackBody, "HPD:Help Desk", "CHG:Infrastructure Change", 1)
+	change = strings.Replace(change, `"evt-1"`, `"evt-2"`, 1)
+
+	assert.Equal(t, http.StatusNoContent, postCallback(h, testCallbackBody, headers))
+	assert.Equal(t, http.StatusNoContent, postCallback(h, change, headers))
+	assert.Equal(t, []string{"default:HPD:Help Desk", "change:CHG:Infrastructure Change"}, forms)
+}
+
+func TestCallbackHandler_InvalidRequests(t *testing.T) {
+	headers := map[string]string{DefaultCallbackSecretHeader: "token"}
+	h := newTestCallbackHandler(WithCallbackSecret("", "token"), WithCallbackMaxBody(512))
+	h.Handle("", func(context.Context, *CallbackEvent) error { return nil })
+
+	assert.Equal(t, http.StatusBadRequest, postCallback(h, `{"form": "F"`, headers))
+	assert.Equal(t, http.StatusBadRequest, postCallback(h, `{"timestamp": 1718000000}`, headers))
+	assert.Equal(t, http.StatusBadRequest, postCallback(h, `{"form": "F"}`, headers))
+	assert.Equal(t, http.StatusRequestEntityTooLarge,
+		postCallback(h, `{"form": "`+strings.Repeat("x", 600)+`"}`, headers))
+
+	req := httptest.NewRequest(http.MethodGet, "/callback", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestCallbackHandler_EventIDFromBody(t *testing.T) {
+	headers := map[string]string{DefaultCallbackSecretHeader: "token"}
+	h := newTestCallbackHandler(WithCallbackSecret("", "token"))
+
+	var ids []string
+	h.Handle("", func(_ context.Context, e *CallbackEvent) error {
+		ids = append(ids, e.EventID)
+		return nil
+	})
+
+	body := `{"form": "F", "entryId": "1", "timestamp": "2024-06-10T06:13:20.000+0000"}`
+	assert.Equal(t, http.StatusNoContent, postCallback(h, body, headers))
+	assert.Equal(t, http.StatusConflict, postCallback(h, body, headers))
+
+	require.Len(t, ids, 1)
+	assert.Len(t, ids[0], 64)
+}
@@ -0,0 +1,129 @@
+package remedy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// ErrNoCheckpoint is returned by CheckpointStore.Load when no checkpoint
+// has been saved under the key.
+var ErrNoCheckpoint = errors.New("remedy: no checkpoint")
+
+// CheckpointStore persists progress of long-running operations so they can
+// resume after a restart. Implementations must be safe for concurrent use.
+type CheckpointStore interface {
+	// Load returns the data saved under key, or ErrNoCheckpoint.
+	Load(ctx context.Context, key string) ([]byte, error)
+
+	// Save stores data under key, replacing any previous checkpoint.
+	Save(ctx context.Context, key string, data []byte) error
+}
+
+// FileCheckpointStore stores checkpoints as files in a directory.
+// Each save is written to a temporary file and renamed into place, so a
+// crash never leaves a partially written checkpoint.
+type FileCheckpointStore struct {
+	dir string
+}
+
+// NewFileCheckpointStore creates a checkpoint store in dir.
+// The directory is created on first save if it does not exist.
+func NewFileCheckpointStore(dir string) *FileCheckpointStore {
+	return &FileCheckpointStore{dir: dir}
+}
+
+// Load implements CheckpointStore.
+func (s *FileCheckpointStore) Load(_ context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNoCheckpoint
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading checkpoint: %w", err)
+	}
+
+	return data, nil
+}
+
+// Save implements CheckpointStore.
+func (s *FileCheckpointStore) Save(_ context.Context, key string, data []byte) error {
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return fmt.Errorf("creating checkpoint directory: %w", err)
+	}
+
+	if err := writeFileAtomic(s.path(key), data); err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// path returns the file used for key. Keys are escaped so form names with
+// colons, spaces or slashes map to a single file inside the directory.
+func (s *FileCheckpointStore) path(key string) string {
+	return filepath.Join(s.dir, url.PathEscape(key)+".checkpoint")
+}
+
+// writeFileAtomic writes data to a temporary file next to name and renames
+// it into place.
+func writeFileAtomic(name string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name()) // no-op after a successful rename
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
+
+// MemoryCheckpointStore keeps checkpoints in memory.
+// It is useful for tests and for resuming within a single process.
+type MemoryCheckpointStore struct {
+	data map[string][]byte
+	mu   sync.Mutex
+}
+
+// NewMemoryCheckpointStore creates an empty in-memory checkpoint store.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{data: make(map[string][]byte)}
+}
+
+// Load implements CheckpointStore.
+func (s *MemoryCheckpointStore) Load(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, ok := s.data[key]
+	if !ok {
+		return nil, ErrNoCheckpoint
+	}
+
+	return slices.Clone(data), nil
+}
+
+// Save implements CheckpointStore.
+func (s *MemoryCheckpointStore) Save(_ context.Context, key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data[key] = slices.Clone(data)
+
+	return nil
+}
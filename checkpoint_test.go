@@ -0,0 +1,44 @@
+package remedy
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCheckpointStore(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileCheckpointStore(dir)
+
+	_, err := store.Load(t.Context(), "HPD:Help Desk/import")
+	require.ErrorIs(t, err, ErrNoCheckpoint)
+
+	require.NoError(t, store.Save(t.Context(), "HPD:Help Desk/import", []byte("first")))
+	require.NoError(t, store.Save(t.Context(), "HPD:Help Desk/import", []byte("second")))
+
+	data, err := store.Load(t.Context(), "HPD:Help Desk/import")
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+
+	// The key maps to a single file and no temporary files are left behind
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestMemoryCheckpointStore(t *testing.T) {
+	store := NewMemoryCheckpointStore()
+
+	_, err := store.Load(t.Context(), "key")
+	require.ErrorIs(t, err, ErrNoCheckpoint)
+
+	data := []byte("state")
+	require.NoError(t, store.Save(t.Context(), "key", data))
+	data[0] = 'X' // later changes by the caller must not affect the stored copy
+
+	loaded, err := store.Load(t.Context(), "key")
+	require.NoError(t, err)
+	assert.Equal(t, "state", string(loaded))
+}
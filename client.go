@@ -86,8 +86,15 @@ type Client struct {
 	autoRefresh      bool
 	refreshMu        sync.Mutex // serializes token refresh attempts
 
+	// Selection field mapping
+	selectionFormat SelectionFormat
+
+	// Maximum attachment size in bytes, or 0 for no limit
+	maxAttachmentSize int64
+
 	entries     *entryService
 	attachments *attachmentService
+	forms       *formService
 }
 
 // New creates a new Remedy client with the specified base URL and options.
@@ -108,6 +115,7 @@ func New(baseURL string, opts ...Option) *Client {
 
 	c.entries = &entryService{client: c}
 	c.attachments = &attachmentService{client: c}
+	c.forms = &formService{client: c}
 
 	return c
 }
@@ -122,6 +130,11 @@ func (c *Client) Attachments() AttachmentServicer {
 	return c.attachments
 }
 
+// Forms returns the form service for form metadata.
+func (c *Client) Forms() FormServicer {
+	return c.forms
+}
+
 // Close releases resources associated with the client.
 func (c *Client) Close() {
 	c.queue.Close()
@@ -339,6 +352,23 @@ func (c *Client) doAndDecode(req *http.Request, cancel context.CancelFunc, targe
 	return c.handleResponse(resp, target)
 }
 
+// getJSON sends a GET request for path through the request queue and
+// decodes the response into target. The queue is released on return, so
+// callers may load form metadata afterwards.
+func (c *Client) getJSON(ctx context.Context, path string, target any) error {
+	if err := c.acquireAndRateLimit(ctx); err != nil {
+		return err
+	}
+	defer c.queue.Release()
+
+	req, cancel, err := c.newJSONRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	return c.doAndDecode(req, cancel, target)
+}
+
 // handleResponse checks the response status and decodes the body.
 func (c *Client) handleResponse(resp *http.Response, target any) error {
 	if resp.StatusCode >= http.StatusBadRequest {
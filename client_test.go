@@ -255,6 +255,16 @@ func TestBuildQueryParams(t *testing.T) {
 				"sort": "Priority.desc",
 			},
 		},
+		{
+			name: "sort by multiple fields",
+			opts: []QueryOption{WithSortBy(
+				SortKey{Field: "Modified Date", Order: SortDesc},
+				SortKey{Field: "Request ID", Order: SortAsc},
+			)},
+			expected: map[string]string{
+				"sort": "Modified Date.desc,Request ID",
+			},
+		},
 		{
 			name: "pagination",
 			opts: []QueryOption{WithLimit(100), WithOffset(50)},
@@ -303,6 +313,14 @@ func TestClient_ContextCancellation(t *testing.T) {
 	assert.ErrorIs(t, err, context.Canceled)
 }
 
+func TestBuildQueryParams_EmptySortField(t *testing.T) {
+	params := buildQueryParams([]QueryOption{WithSort("", SortAsc)})
+	assert.False(t, params.Has("sort"), "empty sort field should not be included")
+
+	params = buildQueryParams([]QueryOption{WithSortBy(SortKey{Field: ""}, SortKey{Field: "Status", Order: SortDesc})})
+	assert.Equal(t, "Status.desc", params.Get("sort"))
+}
+
 func TestBuildQueryParams_RejectsNegativeLimit(t *testing.T) {
 	params := buildQueryParams([]QueryOption{WithLimit(-1)})
 
@@ -0,0 +1,157 @@
+package remedy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+)
+
+// defaultModifyAttempts is the number of read-modify-write attempts made by Modify.
+const defaultModifyAttempts = 3
+
+// ErrConflict indicates an entry was modified by someone else since it was read.
+var ErrConflict = errors.New("remedy: entry modified concurrently")
+
+// ConflictError describes an optimistic concurrency conflict.
+// It matches ErrConflict with errors.Is.
+type ConflictError struct {
+	EntryID string
+
+	// Expected is the Modified Date the caller based its changes on.
+	Expected time.Time
+
+	// Actual is the Modified Date found on the server.
+	Actual time.Time
+}
+
+// Error implements the error interface.
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("remedy: entry %s modified concurrently (expected %s, found %s)",
+		e.EntryID, e.Expected.Format(time.RFC3339), e.Actual.Format(time.RFC3339))
+}
+
+// Is implements errors.Is support for ConflictError.
+func (e *ConflictError) Is(target error) bool {
+	return errors.Is(target, ErrConflict)
+}
+
+// ModifyOption configures Modify.
+type ModifyOption func(*modifyOptions)
+
+// modifyOptions holds the configuration for Modify.
+type modifyOptions struct {
+	attempts int
+}
+
+// WithMaxAttempts sets how many times Modify reads and retries the update
+// after a conflict. The default is 3.
+func WithMaxAttempts(n int) ModifyOption {
+	return func(o *modifyOptions) {
+		o.attempts = n
+	}
+}
+
+// UpdateIfUnmodified updates an entry only if its last modification time
+// (field 6, such as Modified Date or Last Modified Date, as named in the
+// form metadata) still equals expectedModified, returning a *ConflictError
+// otherwise.
+//
+// The check and the update are separate requests, so a concurrent change in
+// the short window between them is not detected; the check prevents the
+// common case of overwriting changes made since the entry was read.
+func UpdateIfUnmodified(ctx context.Context, entries EntryServicer, form, entryID string, expectedModified time.Time, values map[string]any, opts ...WriteOption) error {
+	core, err := coreFieldsOf(ctx, entries, form)
+	if err != nil {
+		return err
+	}
+	field := core.modified
+
+	current, err := entries.Get(ctx, form, entryID, WithFields(field))
+	if err != nil {
+		return fmt.Errorf("reading modified date: %w", err)
+	}
+
+	actual, err := modifiedDate(current, field)
+	if err != nil {
+		return err
+	}
+
+	// AR stores timestamps with one-second precision
+	if !actual.Truncate(time.Second).Equal(expectedModified.Truncate(time.Second)) {
+		return &ConflictError{EntryID: entryID, Expected: expectedModified, Actual: actual}
+	}
+
+	return entries.Update(ctx, form, entryID, values, opts...)
+}
+
+// Modify performs a read-modify-write of an entry. It reads the entry,
+// calls fn to change its values, and writes the changed fields with
+// UpdateIfUnmodified. On a conflict the entry is read again and fn is
+// called again, up to the configured number of attempts, so fn must be
+// safe to repeat. If fn returns an error, Modify returns it without
+// updating. Only fields whose values change according to DiffValues are
+// sent; if nothing changes, no update is sent. Changes are detected per
+// field, so fn should assign new values rather than mutate nested values
+// in place.
+func Modify(ctx context.Context, entries EntryServicer, form, entryID string, fn func(*Entry) error, opts ...ModifyOption) error {
+	o := &modifyOptions{attempts: defaultModifyAttempts}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	core, err := coreFieldsOf(ctx, entries, form)
+	if err != nil {
+		return err
+	}
+
+	for range max(o.attempts, 1) {
+		err = modifyOnce(ctx, entries, form, entryID, fn, core.modified)
+		if !errors.Is(err, ErrConflict) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("giving up after %d attempts: %w", max(o.attempts, 1), err)
+}
+
+// modifyOnce performs a single read-modify-write attempt.
+func modifyOnce(ctx context.Context, entries EntryServicer, form, entryID string, fn func(*Entry) error, field string) error {
+	entry, err := entries.Get(ctx, form, entryID)
+	if err != nil {
+		return err
+	}
+
+	expected, err := modifiedDate(entry, field)
+	if err != nil {
+		return err
+	}
+
+	original := maps.Clone(entry.Values)
+	if err := fn(entry); err != nil {
+		return err
+	}
+
+	diff := DiffValues(Entry{Values: original}, *entry)
+	if len(diff) == 0 {
+		return nil
+	}
+
+	return UpdateIfUnmodified(ctx, entries, form, entryID, expected, diff.Values())
+}
+
+// modifiedDate extracts the last modification time of an entry from field.
+func modifiedDate(e *Entry, field string) (time.Time, error) {
+	v, ok := e.Values[field]
+	if !ok || v == nil {
+		return time.Time{}, fmt.Errorf("entry has no %q value", field)
+	}
+
+	t, err := parseTimeValue(v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing %s: %w", field, err)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,162 @@
+package remedy
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntryService_UpdateIfUnmodified(t *testing.T) {
+	modified := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	updated := false
+
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet {
+			assert.Equal(t, "values(Modified Date)", req.URL.Query().Get("fields"))
+			return newMockResponse(http.StatusOK, Entry{Values: map[string]any{
+				"Modified Date": "2024-05-01T09:00:00.000+0000",
+			}}), nil
+		}
+
+		updated = true
+		return newMockResponse(http.StatusNoContent, nil), nil
+	})
+
+	err := UpdateIfUnmodified(t.Context(), client.Entries(), "Form", "REQ1", modified,
+		map[string]any{"Status": "Closed"})
+
+	require.NoError(t, err)
+	assert.True(t, updated)
+}
+
+func TestEntryService_UpdateIfUnmodified_Conflict(t *testing.T) {
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodGet, req.Method, "conflicting update must not be sent")
+
+		return newMockResponse(http.StatusOK, Entry{Values: map[string]any{
+			"Modified Date": "2024-05-01T09:05:00.000+0000",
+		}}), nil
+	})
+
+	expected := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	err := UpdateIfUnmodified(t.Context(), client.Entries(), "Form", "REQ1", expected,
+		map[string]any{"Status": "Closed"})
+
+	require.ErrorIs(t, err, ErrConflict)
+
+	var conflict *ConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, "REQ1", conflict.EntryID)
+	assert.Equal(t, expected.Add(5*time.Minute), conflict.Actual.UTC())
+}
+
+func TestEntryService_Modify_RetriesOnConflict(t *testing.T) {
+	// The entry changes between the first read and its conflict check, so
+	// the first attempt conflicts and the second succeeds.
+	modifiedDates := []string{
+		"2024-05-01T09:00:00.000+0000", // attempt 1: read
+		"2024-05-01T09:01:00.000+0000", // attempt 1: check
+		"2024-05-01T09:01:00.000+0000", // attempt 2: read
+		"2024-05-01T09:01:00.000+0000", // attempt 2: check
+	}
+	gets := 0
+	var sent map[string]any
+
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet {
+			date := modifiedDates[gets]
+			gets++
+			return newMockResponse(http.StatusOK, Entry{Values: map[string]any{
+				"Modified Date": date,
+				"Status":        "New",
+				"Work Count":    float64(gets),
+			}}), nil
+		}
+
+		var body map[string]map[string]any
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		sent = body["values"]
+
+		return newMockResponse(http.StatusNoContent, nil), nil
+	})
+
+	calls := 0
+	err := Modify(t.Context(), client.Entries(), "Form", "REQ1", func(e *Entry) error {
+		calls++
+		e.Values["Status"] = "Assigned"
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, map[string]any{"Status": "Assigned"}, sent, "only changed fields are sent")
+}
+
+func TestEntryService_Modify_GivesUp(t *testing.T) {
+	gets := 0
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodGet, req.Method)
+		gets++
+
+		// Every read sees a newer modification time
+		return newMockResponse(http.StatusOK, Entry{Values: map[string]any{
+			"Modified Date": float64(1714550400 + gets),
+		}}), nil
+	})
+
+	err := Modify(t.Context(), client.Entries(), "Form", "REQ1", func(e *Entry) error {
+		e.Values["Status"] = "Assigned"
+		return nil
+	}, WithMaxAttempts(2))
+
+	require.ErrorIs(t, err, ErrConflict)
+	assert.Equal(t, 4, gets)
+}
+
+func TestEntryService_Modify_NoChanges(t *testing.T) {
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodGet, req.Method, "no update expected without changes")
+
+		return newMockResponse(http.StatusOK, Entry{Values: map[string]any{
+			"Modified Date": "2024-05-01T09:00:00.000+0000",
+			"Status":        "New",
+		}}), nil
+	})
+
+	err := Modify(t.Context(), client.Entries(), "Form", "REQ1", func(e *Entry) error {
+		e.Values["Status"] = "New"
+		return nil
+	})
+
+	require.NoError(t, err)
+}
+
+func TestEntryService_Modify_ModifiedDateFieldFromMetadata(t *testing.T) {
+	updated := false
+	client := setupFormClient(t, itsmCoreFields, func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet {
+			updated = true
+			return newMockResponse(http.StatusNoContent, nil), nil
+		}
+
+		if fields := req.URL.Query().Get("fields"); fields != "" {
+			assert.Equal(t, "values(Last Modified Date)", fields)
+		}
+		return newMockResponse(http.StatusOK, Entry{Values: map[string]any{
+			"Last Modified Date": "2024-05-01T09:00:00.000+0000",
+			"Status":             "New",
+		}}), nil
+	})
+
+	err := Modify(t.Context(), client.Entries(), "HPD:Help Desk", "INC1", func(e *Entry) error {
+		e.Values["Status"] = "Assigned"
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.True(t, updated)
+}
@@ -0,0 +1,82 @@
+package remedy
+
+import (
+	"context"
+	"fmt"
+)
+
+// CountOption configures Count.
+type CountOption func(*countOptions)
+
+// countOptions holds the configuration for Count.
+type countOptions struct {
+	idField  string
+	pageSize int
+}
+
+// WithCountIDField sets the field holding the entry ID for the ID-only
+// scan. The default is the name of field 1 in the form metadata.
+func WithCountIDField(field string) CountOption {
+	return func(o *countOptions) {
+		o.idField = field
+	}
+}
+
+// WithCountPageSize sets how many IDs are fetched per request during the
+// ID-only scan. The default is 1000.
+func WithCountPageSize(n int) CountOption {
+	return func(o *countOptions) {
+		o.pageSize = n
+	}
+}
+
+// Count returns the number of entries matching the qualification, or all
+// entries of the form if qualification is empty.
+//
+// Count first requests a single entry and uses the total size reported by
+// the server. Servers that do not report it are counted with an ID-only
+// scan; WithCountIDField and WithCountPageSize configure the scan.
+func Count(ctx context.Context, entries EntryServicer, form, qualification string, opts ...CountOption) (int, error) {
+	if form == "" {
+		return 0, ErrEmptyFormName
+	}
+
+	o := &countOptions{
+		pageSize: defaultScanPageSize,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	idField, err := idFieldOf(ctx, entries, form, o.idField)
+	if err != nil {
+		return 0, err
+	}
+
+	probe, err := entries.List(ctx, form,
+		WithQualification(qualification),
+		WithFields(idField),
+		WithLimit(1),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("counting entries: %w", err)
+	}
+
+	if probe.TotalSize != nil {
+		return *probe.TotalSize, nil
+	}
+	if len(probe.Entries) == 0 {
+		return 0, nil
+	}
+
+	count := 0
+	err = scanIDs(ctx, entries, form, qualification, idField, o.pageSize, func(ids []string) error {
+		count += len(ids)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("counting entries: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,70 @@
+package remedy
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntryService_Count_UsesTotalSize(t *testing.T) {
+	requests := 0
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		requests++
+		assert.Equal(t, "1", req.URL.Query().Get("limit"))
+		assert.Equal(t, `'Status' = "New"`, req.URL.Query().Get("q"))
+
+		total := 4213
+		return newMockResponse(http.StatusOK, EntryList{
+			Entries:   []Entry{{Values: map[string]any{"Request ID": "REQ1"}}},
+			TotalSize: &total,
+		}), nil
+	})
+
+	count, err := Count(t.Context(), client.Entries(), "Form", `'Status' = "New"`)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4213, count)
+	assert.Equal(t, 1, requests)
+}
+
+func TestEntryService_Count_FallsBackToIDScan(t *testing.T) {
+	ids := []string{"REQ1", "REQ2", "REQ3", "REQ4", "REQ5"}
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		return idListResponse(t, req, ids), nil
+	})
+
+	count, err := Count(t.Context(), client.Entries(), "Form", "", WithCountPageSize(2))
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+}
+
+func TestEntryService_Count_NoEntries(t *testing.T) {
+	requests := 0
+	client := setupFormClient(t, nil, func(_ *http.Request) (*http.Response, error) {
+		requests++
+		return newMockResponse(http.StatusOK, EntryList{}), nil
+	})
+
+	count, err := Count(t.Context(), client.Entries(), "Form", "1=2")
+
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Equal(t, 1, requests)
+}
+
+func TestEntryService_Count_IDField(t *testing.T) {
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "values(Entry ID)", req.URL.Query().Get("fields"))
+
+		total := 7
+		return newMockResponse(http.StatusOK, EntryList{TotalSize: &total}), nil
+	})
+
+	count, err := Count(t.Context(), client.Entries(), "Form", "", WithCountIDField("Entry ID"))
+
+	require.NoError(t, err)
+	assert.Equal(t, 7, count)
+}
@@ -0,0 +1,266 @@
+package remedy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCurrency indicates a field value could not be parsed as a currency.
+var ErrInvalidCurrency = errors.New("remedy: invalid currency value")
+
+// CurrencyAmount is a decimal value in a specific currency.
+// It is used for the functional currency values of a Currency field.
+// Value holds the decimal text as sent by the server, so amounts such as
+// 0.1 survive a round trip unchanged; use Value.Float64 to compute with it.
+//
+// When passed to the query builder, a CurrencyAmount compares against the
+// field's functional value in that currency:
+//
+//	NewQuery().And("Cost", ">", remedy.CurrencyAmount{Value: "100", Code: "EUR"})
+//	// Result: 'Cost.EUR' > 100
+type CurrencyAmount struct {
+	Value json.Number `json:"decimal"`
+	Code  string      `json:"currency"`
+}
+
+// Currency represents the value of an AR currency field.
+//
+// Currency implements json.Marshaler, so it can be stored directly in
+// Entry.Values for Create and Update. When passed to the query builder,
+// a Currency compares against the entered value and currency code:
+//
+//	NewQuery().And("Cost", ">", remedy.Currency{Value: "100", Code: "USD"})
+//	// Result: ('Cost.VALUE' > 100 AND 'Cost.TYPE' = "USD")
+type Currency struct {
+	// Value is the decimal amount as entered. It keeps the decimal text
+	// rather than a binary float, so amounts are written back exactly;
+	// use Value.Float64 to compute with it.
+	Value json.Number
+
+	// Code is the ISO 4217 currency code of Value.
+	Code string
+
+	// ConversionDate is the date used to convert Value into the
+	// functional currencies. It is zero when not set.
+	ConversionDate time.Time
+
+	// Functional holds Value converted into each functional currency
+	// configured on the server.
+	Functional []CurrencyAmount
+}
+
+// currencyJSON is the wire format of a currency field value.
+type currencyJSON struct {
+	Decimal          json.Number      `json:"decimal"`
+	Currency         string           `json:"currency"`
+	ConversionDate   any              `json:"conversionDate,omitempty"`
+	FunctionalValues []CurrencyAmount `json:"functionalValues,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c Currency) MarshalJSON() ([]byte, error) {
+	wire := currencyJSON{
+		Decimal:          c.Value,
+		Currency:         c.Code,
+		FunctionalValues: c.Functional,
+	}
+	if !c.ConversionDate.IsZero() {
+		wire.ConversionDate = formatTimeValue(c.ConversionDate)
+	}
+
+	return json.Marshal(wire)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Currency) UnmarshalJSON(data []byte) error {
+	var wire currencyJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCurrency, err)
+	}
+
+	parsed := Currency{
+		Value:      wire.Decimal,
+		Code:       wire.Currency,
+		Functional: wire.FunctionalValues,
+	}
+	if wire.ConversionDate != nil {
+		t, err := parseTimeValue(wire.ConversionDate)
+		if err != nil {
+			return fmt.Errorf("%w: conversion date: %w", ErrInvalidCurrency, err)
+		}
+		parsed.ConversionDate = t
+	}
+
+	*c = parsed
+
+	return nil
+}
+
+// FunctionalValue returns the functional value in the given currency.
+// It returns false if the server did not provide a conversion to that currency.
+func (c Currency) FunctionalValue(code string) (json.Number, bool) {
+	for _, amount := range c.Functional {
+		if amount.Code == code {
+			return amount.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers in Values decode as
+// float64, except within currency values, which keep the decimal text of
+// their amounts as json.Number so ParseCurrency can restore them exactly.
+func (e *Entry) UnmarshalJSON(data []byte) error {
+	type entryJSON Entry
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var decoded entryJSON
+	if err := dec.Decode(&decoded); err != nil {
+		return err
+	}
+	for field, v := range decoded.Values {
+		if !isCurrencyObject(v) {
+			decoded.Values[field] = numbersToFloat(v)
+		}
+	}
+	*e = Entry(decoded)
+
+	return nil
+}
+
+// isCurrencyObject reports whether v is the JSON object of a currency value.
+func isCurrencyObject(v any) bool {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return false
+	}
+	_, hasDecimal := m["decimal"]
+	_, hasCurrency := m["currency"]
+
+	return hasDecimal && hasCurrency
+}
+
+// numbersToFloat replaces the json.Number values in v with float64, as
+// decoding without UseNumber would produce.
+func numbersToFloat(v any) any {
+	switch val := v.(type) {
+	case json.Number:
+		f, _ := val.Float64() // the decoder only produces valid numbers
+		return f
+	case map[string]any:
+		for k, x := range val {
+			val[k] = numbersToFloat(x)
+		}
+	case []any:
+		for i, x := range val {
+			val[i] = numbersToFloat(x)
+		}
+	}
+
+	return v
+}
+
+// ParseCurrency converts a currency field value from Entry.Values into a Currency.
+// It returns false if the value is nil (the field is not set).
+func ParseCurrency(v any) (Currency, bool, error) {
+	switch val := v.(type) {
+	case nil:
+		return Currency{}, false, nil
+	case Currency:
+		return val, true, nil
+	case map[string]any:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return Currency{}, false, fmt.Errorf("%w: %w", ErrInvalidCurrency, err)
+		}
+
+		var c Currency
+		if err := json.Unmarshal(data, &c); err != nil {
+			return Currency{}, false, err
+		}
+		return c, true, nil
+	default:
+		return Currency{}, false, fmt.Errorf("%w: expected object, got %T", ErrInvalidCurrency, v)
+	}
+}
+
+// Currency parses the named currency field of the entry.
+// It returns false if the field is not set.
+func (e *Entry) Currency(field string) (Currency, bool, error) {
+	return ParseCurrency(e.Values[field])
+}
+
+// formatCurrencyCondition formats a condition on a currency field.
+// Currency values compare the entered value and code; CurrencyAmount values
+// compare the functional value in the given currency.
+func formatCurrencyCondition(field, op string, value any) (string, bool) {
+	escapedField := escapeFieldName(field)
+
+	switch val := value.(type) {
+	case Currency:
+		condition := fmt.Sprintf("'%s.VALUE' %s %s", escapedField, op, formatAmount(val.Value))
+		if val.Code == "" {
+			return condition, true
+		}
+		return fmt.Sprintf("(%s AND '%s.TYPE' = %q)", condition, escapedField, val.Code), true
+	case CurrencyAmount:
+		return fmt.Sprintf("'%s.%s' %s %s", escapedField, escapeFieldName(val.Code), op, formatAmount(val.Value)), true
+	default:
+		return "", false
+	}
+}
+
+// formatDecimal formats a decimal without exponent notation, which AR
+// qualifications do not accept.
+func formatDecimal(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// formatAmount formats a currency amount for a qualification. Amounts in
+// exponent notation, which AR does not accept, are expanded; text that is
+// not a number is quoted so it cannot alter the qualification.
+func formatAmount(n json.Number) string {
+	if n == "" {
+		return "0"
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		return strconv.Quote(n.String())
+	}
+	if strings.ContainsAny(n.String(), "eE") {
+		return formatDecimal(f)
+	}
+
+	return n.String()
+}
+
+// amountEqual reports whether two currency amounts are the same decimal
+// value, so that "10.5" equals "10.50". Empty amounts are zero.
+func amountEqual(a, b json.Number) bool {
+	ra, okA := amountRat(a)
+	rb, okB := amountRat(b)
+	if !okA || !okB {
+		return a == b
+	}
+
+	return ra.Cmp(rb) == 0
+}
+
+// amountRat converts a currency amount to an exact rational number.
+func amountRat(n json.Number) (*big.Rat, bool) {
+	if n == "" {
+		return new(big.Rat), true
+	}
+
+	return new(big.Rat).SetString(n.String())
+}
@@ -0,0 +1,175 @@
+package remedy
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntry_Currency(t *testing.T) {
+	var entry Entry
+	err := json.Unmarshal([]byte(`{"values": {"Cost": {
+		"decimal": 1250.75,
+		"currency": "USD",
+		"conversionDate": "2024-03-01T00:00:00.000+0000",
+		"functionalValues": [
+			{"decimal": 1150.2, "currency": "EUR"},
+			{"decimal": 980.1, "currency": "GBP"}
+		]
+	}}}`), &entry)
+	require.NoError(t, err)
+
+	cost, ok, err := entry.Currency("Cost")
+
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, json.Number("1250.75"), cost.Value)
+	assert.Equal(t, "USD", cost.Code)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), cost.ConversionDate.UTC())
+	require.Len(t, cost.Functional, 2)
+
+	eur, ok := cost.FunctionalValue("EUR")
+	require.True(t, ok)
+	assert.Equal(t, json.Number("1150.2"), eur)
+
+	_, ok = cost.FunctionalValue("JPY")
+	assert.False(t, ok)
+}
+
+func TestEntry_Currency_NotSet(t *testing.T) {
+	entry := Entry{Values: map[string]any{"Cost": nil}}
+
+	_, ok, err := entry.Currency("Cost")
+
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestParseCurrency_Invalid(t *testing.T) {
+	_, _, err := ParseCurrency("100 USD")
+	require.ErrorIs(t, err, ErrInvalidCurrency)
+
+	_, _, err = ParseCurrency(map[string]any{"decimal": "lots"})
+	assert.ErrorIs(t, err, ErrInvalidCurrency)
+}
+
+func TestCurrency_MarshalJSON_RoundTrip(t *testing.T) {
+	original := Currency{
+		Value:          "99.5",
+		Code:           "EUR",
+		ConversionDate: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Functional:     []CurrencyAmount{{Value: "108.3", Code: "USD"}},
+	}
+
+	data, err := json.Marshal(map[string]any{"values": map[string]any{"Cost": original}})
+	require.NoError(t, err)
+
+	var entry Entry
+	require.NoError(t, json.Unmarshal(data, &entry))
+
+	decoded, ok, err := entry.Currency("Cost")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, original.Value, decoded.Value)
+	assert.Equal(t, original.Code, decoded.Code)
+	assert.True(t, original.ConversionDate.Equal(decoded.ConversionDate))
+	assert.Equal(t, original.Functional, decoded.Functional)
+}
+
+func TestCurrency_KeepsDecimalPrecision(t *testing.T) {
+	var c Currency
+	require.NoError(t, json.Unmarshal([]byte(`{"decimal": 0.30000000000000000001, "currency": "USD"}`), &c))
+	assert.Equal(t, json.Number("0.30000000000000000001"), c.Value)
+
+	data, err := json.Marshal(Currency{Value: "0.1", Code: "USD"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"decimal": 0.1, "currency": "USD"}`, string(data))
+	assert.Contains(t, string(data), `"decimal":0.1,`)
+}
+
+func TestEntry_Currency_KeepsDecimalPrecision(t *testing.T) {
+	var entry Entry
+	require.NoError(t, json.Unmarshal([]byte(`{"values": {
+		"Cost": {"decimal": 12345678901234567.89, "currency": "USD",
+			"functionalValues": [{"decimal": 0.30000000000000000001, "currency": "EUR"}]},
+		"Priority": 2,
+		"Effort": [1.5]
+	}}`), &entry))
+
+	cost, ok, err := entry.Currency("Cost")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, json.Number("12345678901234567.89"), cost.Value)
+	eur, _ := cost.FunctionalValue("EUR")
+	assert.Equal(t, json.Number("0.30000000000000000001"), eur)
+
+	// other numbers decode as float64
+	assert.Equal(t, float64(2), entry.Values["Priority"])
+	assert.Equal(t, []any{1.5}, entry.Values["Effort"])
+}
+
+func TestEntryService_Get_KeepsCurrencyPrecision(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(_ *http.Request) (*http.Response, error) {
+		return newMockResponse(http.StatusOK, json.RawMessage(
+			`{"values": {"Cost": {"decimal": 12345678901234567.89, "currency": "USD"}}}`)), nil
+	})
+
+	entry, err := client.Entries().Get(t.Context(), "Form", "REQ1")
+	require.NoError(t, err)
+
+	cost, ok, err := entry.Currency("Cost")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, json.Number("12345678901234567.89"), cost.Value)
+}
+
+func TestCurrency_MarshalJSON_OmitsUnsetFields(t *testing.T) {
+	data, err := json.Marshal(Currency{Value: "10", Code: "USD"})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"decimal": 10, "currency": "USD"}`, string(data))
+}
+
+func TestQuery_Currency(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{
+			name:     "value with currency code",
+			value:    Currency{Value: "100", Code: "USD"},
+			expected: `('Cost.VALUE' > 100 AND 'Cost.TYPE' = "USD")`,
+		},
+		{
+			name:     "value without currency code",
+			value:    Currency{Value: "1500000.5"},
+			expected: `'Cost.VALUE' > 1500000.5`,
+		},
+		{
+			name:     "functional currency value",
+			value:    CurrencyAmount{Value: "100", Code: "EUR"},
+			expected: `'Cost.EUR' > 100`,
+		},
+		{
+			name:     "exponent notation",
+			value:    Currency{Value: "1.5e6"},
+			expected: `'Cost.VALUE' > 1500000`,
+		},
+		{
+			name:     "not a number",
+			value:    Currency{Value: `1 OR 'Status' = 0`},
+			expected: `'Cost.VALUE' > "1 OR 'Status' = 0"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewQuery().And("Cost", ">", tt.value).Build())
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package remedy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+)
+
+// ErrInvalidDiary indicates a field value could not be parsed as a diary.
+var ErrInvalidDiary = errors.New("remedy: invalid diary value")
+
+// DiaryEntry is a single timestamped record in a diary field.
+type DiaryEntry struct {
+	Timestamp time.Time
+	User      string
+	Text      string
+}
+
+// Diary holds the history of a diary field such as Work Log or Notes.
+// Entries are ordered from oldest to newest.
+type Diary []DiaryEntry
+
+// ParseDiary converts a diary field value from Entry.Values into a Diary.
+// The REST API returns diary fields as a list of objects with timestamp,
+// user and text keys. A nil value yields an empty diary.
+func ParseDiary(v any) (Diary, error) {
+	if v == nil {
+		return Diary{}, nil
+	}
+
+	items, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected list, got %T", ErrInvalidDiary, v)
+	}
+
+	diary := make(Diary, 0, len(items))
+	for i, item := range items {
+		record, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: record %d is %T", ErrInvalidDiary, i, item)
+		}
+
+		entry, err := parseDiaryRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("%w: record %d: %w", ErrInvalidDiary, i, err)
+		}
+		diary = append(diary, entry)
+	}
+
+	slices.SortStableFunc(diary, func(a, b DiaryEntry) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	return diary, nil
+}
+
+// parseDiaryRecord converts a single diary record map into a DiaryEntry.
+func parseDiaryRecord(record map[string]any) (DiaryEntry, error) {
+	var entry DiaryEntry
+
+	if ts, ok := record["timestamp"]; ok && ts != nil {
+		t, err := parseTimeValue(ts)
+		if err != nil {
+			return DiaryEntry{}, err
+		}
+		entry.Timestamp = t
+	}
+
+	entry.User, _ = record["user"].(string)
+
+	// Older servers report the note text under "value" rather than "text".
+	text, ok := record["text"].(string)
+	if !ok {
+		text, _ = record["value"].(string)
+	}
+	entry.Text = text
+
+	return entry, nil
+}
+
+// Diary parses the named diary field of the entry.
+func (e *Entry) Diary(field string) (Diary, error) {
+	return ParseDiary(e.Values[field])
+}
+
+// All iterates over the diary entries from oldest to newest.
+func (d Diary) All() iter.Seq2[int, DiaryEntry] {
+	return slices.All(d)
+}
+
+// Backward iterates over the diary entries from newest to oldest.
+func (d Diary) Backward() iter.Seq2[int, DiaryEntry] {
+	return slices.Backward(d)
+}
+
+// Latest returns the most recent diary entry.
+// It returns false if the diary is empty.
+func (d Diary) Latest() (DiaryEntry, bool) {
+	if len(d) == 0 {
+		return DiaryEntry{}, false
+	}
+
+	return d[len(d)-1], true
+}
+
+// AppendDiary adds a new record to a diary field of an existing entry.
+// The server timestamps the record and attributes it to the current user;
+// writing a diary field appends rather than replaces its history.
+func AppendDiary(ctx context.Context, entries EntryServicer, form, entryID, field, text string) error {
+	if field == "" {
+		return ErrEmptyFieldName
+	}
+
+	return entries.Update(ctx, form, entryID, map[string]any{field: text})
+}
@@ -0,0 +1,100 @@
+package remedy
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDiary(t *testing.T) {
+	// Decode through JSON so values have the same types as Entry.Values
+	var entry Entry
+	err := json.Unmarshal([]byte(`{"values": {"Work Log": [
+		{"timestamp": "2024-01-15T12:00:00.000+0000", "user": "Bob", "text": "second"},
+		{"timestamp": "2024-01-15T10:30:00.000+0000", "user": "Alice", "text": "first"}
+	]}}`), &entry)
+	require.NoError(t, err)
+
+	diary, err := entry.Diary("Work Log")
+
+	require.NoError(t, err)
+	require.Len(t, diary, 2)
+	assert.Equal(t, "Alice", diary[0].User)
+	assert.Equal(t, "first", diary[0].Text)
+	assert.Equal(t, time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC), diary[0].Timestamp.UTC())
+
+	latest, ok := diary.Latest()
+	require.True(t, ok)
+	assert.Equal(t, "second", latest.Text)
+}
+
+func TestParseDiary_Nil(t *testing.T) {
+	diary, err := ParseDiary(nil)
+
+	require.NoError(t, err)
+	assert.Empty(t, diary)
+
+	_, ok := diary.Latest()
+	assert.False(t, ok)
+}
+
+func TestParseDiary_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{name: "not a list", value: "plain text"},
+		{name: "record not an object", value: []any{"text"}},
+		{name: "bad timestamp", value: []any{map[string]any{"timestamp": "yesterday"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDiary(tt.value)
+			assert.ErrorIs(t, err, ErrInvalidDiary)
+		})
+	}
+}
+
+func TestDiary_Iteration(t *testing.T) {
+	diary := Diary{{Text: "a"}, {Text: "b"}, {Text: "c"}}
+
+	var forward, backward []string
+	for _, e := range diary.All() {
+		forward = append(forward, e.Text)
+	}
+	for _, e := range diary.Backward() {
+		backward = append(backward, e.Text)
+	}
+
+	assert.Equal(t, []string{"a", "b", "c"}, forward)
+	assert.Equal(t, []string{"c", "b", "a"}, backward)
+}
+
+func TestEntryService_AppendDiary(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Contains(t, req.URL.Path, "/REQ000001")
+
+		var body map[string]map[string]any
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, map[string]any{"Work Log": "Called customer"}, body["values"])
+
+		return newMockResponse(http.StatusNoContent, nil), nil
+	})
+
+	err := AppendDiary(t.Context(), client.Entries(), "HPD:Help Desk", "REQ000001", "Work Log", "Called customer")
+	require.NoError(t, err)
+}
+
+func TestEntryService_AppendDiary_EmptyFieldReturnsError(t *testing.T) {
+	client := New("https://remedy.example.com")
+
+	err := AppendDiary(t.Context(), client.Entries(), "Form", "REQ000001", "", "text")
+
+	assert.ErrorIs(t, err, ErrEmptyFieldName)
+}
@@ -0,0 +1,192 @@
+package remedy
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValueChange is the old and new value of a changed field.
+type ValueChange struct {
+	Old any
+	New any
+}
+
+// Diff maps field names to their changes.
+type Diff map[string]ValueChange
+
+// Values returns the new values of the changed fields, suitable for Update.
+func (d Diff) Values() map[string]any {
+	values := make(map[string]any, len(d))
+	for field, change := range d {
+		values[field] = change.New
+	}
+
+	return values
+}
+
+// DiffValues compares the fields of desired against current and returns the
+// fields whose values differ. Only fields present in desired are compared;
+// fields missing from current are treated as null.
+//
+// Values are compared the way AR stores them rather than by Go type:
+//   - null and the empty string are equal
+//   - numbers equal their numeric string form ("3" and 3.0)
+//   - timestamps are compared as instants to one-second precision,
+//     regardless of format or offset, and equal their epoch seconds
+//   - currency values compare amount and currency code
+func DiffValues(current, desired Entry) Diff {
+	diff := make(Diff)
+	for field, v := range desired.Values {
+		old := current.Values[field]
+		if !valuesEqual(old, v) {
+			diff[field] = ValueChange{Old: old, New: v}
+		}
+	}
+
+	return diff
+}
+
+// Patch updates an entry with only the fields of desired that differ from
+// the entry's current values, and returns the applied diff. Unchanged fields
+// are not sent, so they do not fire filters or create audit records. If
+// nothing differs, no update is sent and the returned diff is empty.
+func Patch(ctx context.Context, entries EntryServicer, form, entryID string, desired map[string]any, opts ...WriteOption) (Diff, error) {
+	fields := slices.Sorted(maps.Keys(desired))
+
+	current, err := entries.Get(ctx, form, entryID, WithFields(fields...))
+	if err != nil {
+		return nil, fmt.Errorf("reading current values: %w", err)
+	}
+
+	diff := DiffValues(*current, Entry{Values: desired})
+	if len(diff) == 0 {
+		return diff, nil
+	}
+
+	if err := entries.Update(ctx, form, entryID, diff.Values(), opts...); err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+// valuesEqual reports whether two field values are equivalent in AR.
+func valuesEqual(a, b any) bool {
+	if isEmptyValue(a) || isEmptyValue(b) {
+		return isEmptyValue(a) && isEmptyValue(b)
+	}
+
+	if equal, ok := currencyEqual(a, b); ok {
+		return equal
+	}
+	if equal, ok := timeEqual(a, b); ok {
+		return equal
+	}
+	if equal, ok := numberEqual(a, b); ok {
+		return equal
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// isEmptyValue reports whether v is null or the empty string.
+func isEmptyValue(v any) bool {
+	s, isString := v.(string)
+	return v == nil || (isString && s == "")
+}
+
+// currencyEqual compares values when either is a Currency.
+func currencyEqual(a, b any) (equal, ok bool) {
+	_, aIsCurrency := a.(Currency)
+	_, bIsCurrency := b.(Currency)
+	if !aIsCurrency && !bIsCurrency {
+		return false, false
+	}
+
+	ca, _, errA := ParseCurrency(a)
+	cb, _, errB := ParseCurrency(b)
+	if errA != nil || errB != nil {
+		return false, true
+	}
+
+	return amountEqual(ca.Value, cb.Value) && ca.Code == cb.Code, true
+}
+
+// timeEqual compares values when at least one is a timestamp and the other
+// is a timestamp or epoch seconds.
+func timeEqual(a, b any) (equal, ok bool) {
+	ta, aIsTime := asTimestamp(a)
+	tb, bIsTime := asTimestamp(b)
+
+	switch {
+	case aIsTime && bIsTime:
+	case aIsTime:
+		secs, isNumber := numericValue(b)
+		if !isNumber {
+			return false, false
+		}
+		tb = time.Unix(int64(secs), 0)
+	case bIsTime:
+		secs, isNumber := numericValue(a)
+		if !isNumber {
+			return false, false
+		}
+		ta = time.Unix(int64(secs), 0)
+	default:
+		return false, false
+	}
+
+	return ta.Unix() == tb.Unix(), true
+}
+
+// asTimestamp converts time.Time values and AR timestamp strings.
+func asTimestamp(v any) (time.Time, bool) {
+	switch val := v.(type) {
+	case time.Time:
+		return val, true
+	case string:
+		return parseTimeString(val)
+	default:
+		return time.Time{}, false
+	}
+}
+
+// numberEqual compares values when at least one is a number and the other
+// is a number or numeric string.
+func numberEqual(a, b any) (equal, ok bool) {
+	na, aIsNumber := numericValue(a)
+	nb, bIsNumber := numericValue(b)
+	if !aIsNumber && !bIsNumber {
+		return false, false
+	}
+
+	if !aIsNumber {
+		na, aIsNumber = numericString(a)
+	}
+	if !bIsNumber {
+		nb, bIsNumber = numericString(b)
+	}
+	if !aIsNumber || !bIsNumber {
+		return false, true
+	}
+
+	return na == nb, true
+}
+
+// numericString parses a string holding a number.
+func numericString(v any) (float64, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, false
+	}
+
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+
+	return f, err == nil
+}
@@ -0,0 +1,121 @@
+package remedy
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValuesEqual(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  any
+		equal bool
+	}{
+		{name: "null and empty string", a: nil, b: "", equal: true},
+		{name: "null and value", a: nil, b: "x", equal: false},
+		{name: "number and numeric string", a: float64(3), b: "3", equal: true},
+		{name: "int and float", a: 3, b: float64(3), equal: true},
+		{name: "number and other number", a: float64(3), b: "4", equal: false},
+		{name: "number and label", a: float64(1), b: "Assigned", equal: false},
+		{name: "numeric strings compare as text", a: "001", b: "1", equal: false},
+		{
+			name:  "timestamps in different offsets",
+			a:     "2024-05-01T09:00:00.000+0000",
+			b:     "2024-05-01T11:00:00+02:00",
+			equal: true,
+		},
+		{
+			name:  "timestamp and time.Time",
+			a:     "2024-05-01T09:00:00.000+0000",
+			b:     time.Date(2024, 5, 1, 9, 0, 0, 500, time.UTC),
+			equal: true,
+		},
+		{name: "timestamp and epoch seconds", a: "2024-05-01T09:00:00.000+0000", b: float64(1714554000), equal: true},
+		{name: "different timestamps", a: "2024-05-01T09:00:00.000+0000", b: "2024-05-01T09:00:01.000+0000", equal: false},
+		{
+			name:  "currency and decoded currency",
+			a:     map[string]any{"decimal": 10.5, "currency": "USD", "functionalValues": []any{}},
+			b:     Currency{Value: "10.50", Code: "USD"},
+			equal: true,
+		},
+		{name: "currency code differs", a: Currency{Value: "1", Code: "USD"}, b: Currency{Value: "1", Code: "EUR"}, equal: false},
+		{name: "strings", a: "Open", b: "Open", equal: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.equal, valuesEqual(tt.a, tt.b))
+			assert.Equal(t, tt.equal, valuesEqual(tt.b, tt.a), "comparison must be symmetric")
+		})
+	}
+}
+
+func TestDiffValues(t *testing.T) {
+	current := Entry{Values: map[string]any{
+		"Status":   "New",
+		"Priority": float64(2),
+		"Notes":    nil,
+		"Summary":  "Printer broken",
+	}}
+	desired := Entry{Values: map[string]any{
+		"Status":   "Assigned",
+		"Priority": "2",
+		"Notes":    "",
+		"Assignee": "Alice",
+	}}
+
+	diff := DiffValues(current, desired)
+
+	assert.Equal(t, Diff{
+		"Status":   {Old: "New", New: "Assigned"},
+		"Assignee": {Old: nil, New: "Alice"},
+	}, diff)
+	assert.Equal(t, map[string]any{"Status": "Assigned", "Assignee": "Alice"}, diff.Values())
+}
+
+func TestEntryService_Patch(t *testing.T) {
+	var sent map[string]any
+
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet {
+			assert.Equal(t, "values(Priority,Status)", req.URL.Query().Get("fields"))
+			return newMockResponse(http.StatusOK, Entry{Values: map[string]any{
+				"Status":   "New",
+				"Priority": float64(2),
+			}}), nil
+		}
+
+		var body map[string]map[string]any
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		sent = body["values"]
+
+		return newMockResponse(http.StatusNoContent, nil), nil
+	})
+
+	diff, err := Patch(t.Context(), client.Entries(), "Form", "REQ1", map[string]any{
+		"Status":   "Assigned",
+		"Priority": 2,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, Diff{"Status": {Old: "New", New: "Assigned"}}, diff)
+	assert.Equal(t, map[string]any{"Status": "Assigned"}, sent)
+}
+
+func TestEntryService_Patch_NoChanges(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodGet, req.Method, "no update expected without changes")
+
+		return newMockResponse(http.StatusOK, Entry{Values: map[string]any{"Status": "New"}}), nil
+	})
+
+	diff, err := Patch(t.Context(), client.Entries(), "Form", "REQ1", map[string]any{"Status": "New"})
+
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+}
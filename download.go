@@ -0,0 +1,292 @@
+package remedy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultDownloadRetries is how often Download retries an interrupted transfer.
+	defaultDownloadRetries = 3
+
+	// defaultDownloadRetryDelay is the delay before the first retry of Download.
+	defaultDownloadRetryDelay = time.Second
+)
+
+// DownloadResult describes an attachment written by Download.
+type DownloadResult struct {
+	// Name is the file name stored in the attachment field.
+	Name string
+
+	// Path is the file the attachment was written to.
+	Path string
+
+	// Size is the number of bytes written.
+	Size int64
+
+	// ContentType is the MIME type from the Content-Type header.
+	ContentType string
+
+	// SHA256 is the hex-encoded SHA-256 of the file contents.
+	SHA256 string
+
+	// Retries is the number of interrupted transfers that were retried.
+	Retries int
+}
+
+// DownloadOption configures Download.
+type DownloadOption func(*downloadOptions)
+
+// downloadOptions holds the configuration for Download.
+type downloadOptions struct {
+	retries    int
+	retryDelay time.Duration
+	progress   func(written, total int64)
+}
+
+// WithDownloadRetries sets how often an interrupted transfer, a server error
+// (5xx) or a 429 Too Many Requests response is retried and the delay before
+// the first retry, which doubles for each further retry. Other API errors
+// are not retried. The defaults are 3 retries and one second.
+func WithDownloadRetries(retries int, delay time.Duration) DownloadOption {
+	return func(o *downloadOptions) {
+		o.retries = retries
+		o.retryDelay = delay
+	}
+}
+
+// WithDownloadProgress calls fn after each write with the number of bytes
+// written so far and the total size, or -1 if unknown. After a retry that
+// cannot resume, written starts again from zero.
+func WithDownloadProgress(fn func(written, total int64)) DownloadOption {
+	return func(o *downloadOptions) {
+		o.progress = fn
+	}
+}
+
+// Download writes an attachment to a file at path. The file is written to
+// a temporary file in the same directory and renamed when complete, so path
+// never holds a partial attachment. The SHA-256 of the contents is computed
+// while streaming.
+//
+// Example usage:
+//
+//	result, err := client.Attachments().Download(ctx, "HPD:Help Desk", id,
+//	    "z2AF Work Log01", "archive/screenshot.png",
+//	    remedy.WithDownloadProgress(func(written, total int64) {
+//	        log.Printf("%d/%d bytes", written, total)
+//	    }))
+//
+// Interrupted transfers are retried. If the server advertises range support
+// with Accept-Ranges, a retry requests only the remaining bytes; otherwise
+// the transfer starts over. API errors, ErrAttachmentTooLarge and context
+// cancellation are not retried.
+func (s *attachmentService) Download(ctx context.Context, form, entryID, fieldName, path string, opts ...DownloadOption) (*DownloadResult, error) {
+	if fieldName == "" {
+		return nil, ErrEmptyFieldName
+	}
+
+	o := &downloadOptions{
+		retries:    defaultDownloadRetries,
+		retryDelay: defaultDownloadRetryDelay,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	info, err := s.info(ctx, form, entryID, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary file: %w", err)
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name()) // fails harmlessly after the rename
+	}()
+
+	d := &attachmentDownload{
+		service: s,
+		path:    attachmentPath(form, entryID, fieldName),
+		opts:    o,
+		file:    tmp,
+		hash:    sha256.New(),
+		total:   info.Size,
+	}
+	if err := d.run(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		return nil, fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, fmt.Errorf("renaming temporary file: %w", err)
+	}
+
+	return &DownloadResult{
+		Name:        info.Name,
+		Path:        path,
+		Size:        d.written,
+		ContentType: d.contentType,
+		SHA256:      hex.EncodeToString(d.hash.Sum(nil)),
+		Retries:     d.retries,
+	}, nil
+}
+
+// attachmentDownload is the state of a Download in progress.
+type attachmentDownload struct {
+	service *attachmentService
+	path    string
+	opts    *downloadOptions
+	file    *os.File
+	hash    hash.Hash
+
+	written     int64
+	total       int64
+	ranges      bool
+	retries     int
+	contentType string
+}
+
+// run transfers the attachment, retrying interrupted transfers.
+func (d *attachmentDownload) run(ctx context.Context) error {
+	delay := d.opts.retryDelay
+
+	for {
+		err := d.transfer(ctx)
+		if err == nil {
+			return nil
+		}
+		if !retryableDownload(ctx, err) || d.retries >= d.opts.retries {
+			return fmt.Errorf("downloading attachment: %w", err)
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+		delay *= 2
+		d.retries++
+	}
+}
+
+// transfer requests the attachment, resuming after the bytes already
+// written if the server supports ranges, and copies it to the file.
+func (d *attachmentDownload) transfer(ctx context.Context) error {
+	offset := int64(0)
+	if d.ranges {
+		offset = d.written
+	}
+
+	body, resp, err := d.service.open(ctx, d.path, offset)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = body.Close()
+	}()
+
+	if !d.resumes(resp, offset) {
+		if err := d.reset(); err != nil {
+			return err
+		}
+		offset = 0
+	}
+	if offset == 0 {
+		d.ranges = resp.Header.Get("Accept-Ranges") == "bytes"
+		d.contentType = resp.Header.Get("Content-Type")
+		if resp.ContentLength >= 0 {
+			d.total = resp.ContentLength
+		}
+	}
+	if err := d.service.client.checkAttachmentSize(d.total); err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(d, d.service.client.limitAttachment(body, offset)); err != nil {
+		return err
+	}
+	if d.total >= 0 && d.written < d.total {
+		return fmt.Errorf("received %d of %d bytes: %w", d.written, d.total, io.ErrUnexpectedEOF)
+	}
+
+	return nil
+}
+
+// resumes reports whether resp continues the file at offset.
+func (d *attachmentDownload) resumes(resp *http.Response, offset int64) bool {
+	if offset == 0 {
+		return d.written == 0
+	}
+	if resp.StatusCode != http.StatusPartialContent {
+		return false
+	}
+
+	// Content-Range: bytes <first>-<last>/<total>
+	first, _, ok := strings.Cut(strings.TrimPrefix(resp.Header.Get("Content-Range"), "bytes "), "-")
+	start, err := strconv.ParseInt(first, 10, 64)
+
+	return ok && err == nil && start == offset
+}
+
+// reset discards the bytes written so far to start the transfer over.
+func (d *attachmentDownload) reset() error {
+	if _, err := d.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("resetting temporary file: %w", err)
+	}
+	if err := d.file.Truncate(0); err != nil {
+		return fmt.Errorf("resetting temporary file: %w", err)
+	}
+
+	d.hash.Reset()
+	d.written = 0
+
+	return nil
+}
+
+// Write writes to the file and the hash and reports progress.
+func (d *attachmentDownload) Write(p []byte) (int, error) {
+	n, err := d.file.Write(p)
+	d.hash.Write(p[:n])
+	d.written += int64(n)
+
+	if d.opts.progress != nil {
+		d.opts.progress(d.written, d.total)
+	}
+
+	return n, err
+}
+
+// retryableDownload reports whether a failed transfer may be retried.
+// Server errors and 429 Too Many Requests are retried; other API errors
+// are permanent.
+func retryableDownload(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ErrAttachmentTooLarge) {
+		return false
+	}
+	if apiErr, ok := errors.AsType[*APIError](err); ok {
+		return apiErr.StatusCode >= http.StatusInternalServerError ||
+			apiErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	_, fileErr := errors.AsType[*os.PathError](err)
+
+	return !fileErr
+}
@@ -0,0 +1,232 @@
+package remedy
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errConnectionReset = errors.New("connection reset by peer")
+
+// downloadData is the attachment served by download tests.
+var downloadData = bytes.Repeat([]byte("0123456789"), 1000)
+
+// interruptedBody returns data and then fails with errConnectionReset.
+func interruptedBody(data []byte) io.ReadCloser {
+	return io.NopCloser(io.MultiReader(bytes.NewReader(data), iotest.ErrReader(errConnectionReset)))
+}
+
+// downloadClient serves the attachment field value and passes download
+// requests to download along with their number, starting at 1.
+func downloadClient(t *testing.T, download func(n int, req *http.Request) *http.Response) *Client {
+	t.Helper()
+
+	downloads := 0
+	return setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/attach/AttachField") {
+			return attachmentFieldResponse("AttachField", map[string]any{
+				"name": "data.txt", "sizeBytes": len(downloadData),
+			}), nil
+		}
+		downloads++
+		return download(downloads, req), nil
+	})
+}
+
+// rangeResponse returns a 206 response with data from offset.
+func rangeResponse(offset int) *http.Response {
+	resp := &http.Response{
+		StatusCode:    http.StatusPartialContent,
+		Body:          io.NopCloser(bytes.NewReader(downloadData[offset:])),
+		Header:        make(http.Header),
+		ContentLength: int64(len(downloadData) - offset),
+	}
+	resp.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, len(downloadData)-1, len(downloadData)))
+
+	return resp
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestAttachmentService_Download(t *testing.T) {
+	client := downloadClient(t, func(_ int, req *http.Request) *http.Response {
+		assert.Empty(t, req.Header.Get("Range"))
+		return downloadResponse(downloadData, "text/plain", true)()
+	})
+
+	path := filepath.Join(t.TempDir(), "data.txt")
+	var progress []int64
+
+	result, err := client.Attachments().Download(t.Context(), "Form", "EntryID", "AttachField", path,
+		WithDownloadProgress(func(written, total int64) {
+			assert.Equal(t, int64(len(downloadData)), total)
+			progress = append(progress, written)
+		}))
+
+	require.NoError(t, err)
+	assert.Equal(t, &DownloadResult{
+		Name:        "data.txt",
+		Path:        path,
+		Size:        int64(len(downloadData)),
+		ContentType: "text/plain",
+		SHA256:      sha256Hex(downloadData),
+	}, result)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, downloadData, data)
+
+	require.NotEmpty(t, progress)
+	assert.Equal(t, int64(len(downloadData)), progress[len(progress)-1])
+
+	// only the downloaded file is left in the directory
+	files, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestAttachmentService_Download_ResumesWithRange(t *testing.T) {
+	client := downloadClient(t, func(n int, req *http.Request) *http.Response {
+		if n == 1 {
+			resp := downloadResponse(nil, "", true)()
+			resp.Header.Set("Accept-Ranges", "bytes")
+			resp.ContentLength = int64(len(downloadData))
+			resp.Body = interruptedBody(downloadData[:4000])
+			return resp
+		}
+
+		assert.Equal(t, "bytes=4000-", req.Header.Get("Range"))
+		return rangeResponse(4000)
+	})
+
+	path := filepath.Join(t.TempDir(), "data.txt")
+	result, err := client.Attachments().Download(t.Context(), "Form", "EntryID", "AttachField", path,
+		WithDownloadRetries(1, time.Millisecond))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Retries)
+	assert.Equal(t, sha256Hex(downloadData), result.SHA256)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, downloadData, data)
+}
+
+func TestAttachmentService_Download_RestartsWithoutRangeSupport(t *testing.T) {
+	client := downloadClient(t, func(n int, req *http.Request) *http.Response {
+		assert.Empty(t, req.Header.Get("Range"))
+
+		resp := downloadResponse(downloadData, "", true)()
+		if n == 1 {
+			// a truncated body without an error
+			resp.Body = io.NopCloser(bytes.NewReader(downloadData[:4000]))
+		}
+		return resp
+	})
+
+	path := filepath.Join(t.TempDir(), "data.txt")
+	result, err := client.Attachments().Download(t.Context(), "Form", "EntryID", "AttachField", path,
+		WithDownloadRetries(1, time.Millisecond))
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(downloadData)), result.Size)
+	assert.Equal(t, sha256Hex(downloadData), result.SHA256)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, downloadData, data)
+}
+
+func TestAttachmentService_Download_RestartsWhenRangeIgnored(t *testing.T) {
+	client := downloadClient(t, func(n int, _ *http.Request) *http.Response {
+		resp := downloadResponse(downloadData, "", true)()
+		resp.Header.Set("Accept-Ranges", "bytes")
+		if n == 1 {
+			resp.Body = interruptedBody(downloadData[:4000])
+		}
+		// the second response ignores the Range header and sends 200
+		return resp
+	})
+
+	path := filepath.Join(t.TempDir(), "data.txt")
+	result, err := client.Attachments().Download(t.Context(), "Form", "EntryID", "AttachField", path,
+		WithDownloadRetries(1, time.Millisecond))
+
+	require.NoError(t, err)
+	assert.Equal(t, sha256Hex(downloadData), result.SHA256)
+}
+
+func TestAttachmentService_Download_Failure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.txt")
+	require.NoError(t, os.WriteFile(path, []byte("previous"), 0o600))
+
+	client := downloadClient(t, func(int, *http.Request) *http.Response {
+		resp := downloadResponse(nil, "", true)()
+		resp.ContentLength = int64(len(downloadData))
+		resp.Body = interruptedBody(downloadData[:10])
+		return resp
+	})
+
+	_, err := client.Attachments().Download(t.Context(), "Form", "EntryID", "AttachField", path,
+		WithDownloadRetries(2, time.Millisecond))
+
+	require.ErrorIs(t, err, errConnectionReset)
+
+	// the existing file is untouched and the temporary file removed
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "previous", string(data))
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestAttachmentService_Download_APIErrorNotRetried(t *testing.T) {
+	client := downloadClient(t, func(n int, _ *http.Request) *http.Response {
+		assert.Equal(t, 1, n)
+		return newMockResponse(http.StatusForbidden, []apiErrorResponse{{MessageType: "ERROR", MessageText: "Access denied"}})
+	})
+
+	_, err := client.Attachments().Download(t.Context(), "Form", "EntryID", "AttachField",
+		filepath.Join(t.TempDir(), "data.txt"), WithDownloadRetries(3, time.Millisecond))
+
+	require.ErrorIs(t, err, ErrForbidden)
+}
+
+func TestAttachmentService_Download_RetriesServerErrors(t *testing.T) {
+	client := downloadClient(t, func(n int, _ *http.Request) *http.Response {
+		switch n {
+		case 1:
+			return newMockResponse(http.StatusServiceUnavailable, nil)
+		case 2:
+			return newMockResponse(http.StatusTooManyRequests, nil)
+		default:
+			return downloadResponse(downloadData, "", true)()
+		}
+	})
+
+	result, err := client.Attachments().Download(t.Context(), "Form", "EntryID", "AttachField",
+		filepath.Join(t.TempDir(), "data.txt"), WithDownloadRetries(3, time.Millisecond))
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Retries)
+	assert.Equal(t, sha256Hex(downloadData), result.SHA256)
+}
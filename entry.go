@@ -26,10 +26,10 @@ func (s *entryService) Get(ctx context.Context, form, entryID string, opts ...Qu
 		return nil, ErrEmptyEntryID
 	}
 
-	if err := s.client.acquireAndRateLimit(ctx); err != nil {
+	selections, err := s.client.selectionsFor(ctx, form)
+	if err != nil {
 		return nil, err
 	}
-	defer s.client.queue.Release()
 
 	path := entryIDPath(form, entryID)
 	params := buildQueryParams(opts)
@@ -38,16 +38,16 @@ func (s *entryService) Get(ctx context.Context, form, entryID string, opts ...Qu
 		path += "?" + params.Encode()
 	}
 
-	req, cancel, err := s.client.newJSONRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating get request: %w", err)
-	}
-
 	var entry Entry
-	if err := s.client.doAndDecode(req, cancel, &entry); err != nil {
+	if err := s.client.getJSON(ctx, path, &entry); err != nil {
 		return nil, fmt.Errorf("getting entry: %w", err)
 	}
 
+	selections.apply(entry.Values, s.client.selectionFormat)
+	if err := s.mapEmbedded(ctx, &entry); err != nil {
+		return nil, err
+	}
+
 	return &entry, nil
 }
 
@@ -57,10 +57,10 @@ func (s *entryService) List(ctx context.Context, form string, opts ...QueryOptio
 		return nil, ErrEmptyFormName
 	}
 
-	if err := s.client.acquireAndRateLimit(ctx); err != nil {
+	selections, err := s.client.selectionsFor(ctx, form)
+	if err != nil {
 		return nil, err
 	}
-	defer s.client.queue.Release()
 
 	path := entryPath(form)
 	params := buildQueryParams(opts)
@@ -69,25 +69,38 @@ func (s *entryService) List(ctx context.Context, form string, opts ...QueryOptio
 		path += "?" + params.Encode()
 	}
 
-	req, cancel, err := s.client.newJSONRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating list request: %w", err)
-	}
-
 	var list EntryList
-	if err := s.client.doAndDecode(req, cancel, &list); err != nil {
+	if err := s.client.getJSON(ctx, path, &list); err != nil {
 		return nil, fmt.Errorf("listing entries: %w", err)
 	}
 
+	for i := range list.Entries {
+		selections.apply(list.Entries[i].Values, s.client.selectionFormat)
+		if err := s.mapEmbedded(ctx, &list.Entries[i]); err != nil {
+			return nil, err
+		}
+	}
+
 	return &list, nil
 }
 
 // Create creates a new entry in the specified form.
-func (s *entryService) Create(ctx context.Context, form string, values map[string]any) (*Entry, error) {
+// Use WithReturnFields to receive server-generated fields in the response.
+func (s *entryService) Create(ctx context.Context, form string, values map[string]any, opts ...WriteOption) (*Entry, error) {
 	if form == "" {
 		return nil, ErrEmptyFormName
 	}
 
+	selections, err := s.client.selectionsFor(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err = selections.toValues(values)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := s.client.acquireAndRateLimit(ctx); err != nil {
 		return nil, err
 	}
@@ -95,7 +108,9 @@ func (s *entryService) Create(ctx context.Context, form string, values map[strin
 
 	body := map[string]any{"values": values}
 
-	req, cancel, err := s.client.newJSONRequest(ctx, http.MethodPost, entryPath(form), body)
+	path := buildWriteOptions(opts).path(entryPath(form))
+
+	req, cancel, err := s.client.newJSONRequest(ctx, http.MethodPost, path, body)
 	if err != nil {
 		return nil, fmt.Errorf("creating create request: %w", err)
 	}
@@ -110,8 +125,21 @@ func (s *entryService) Create(ctx context.Context, form string, values map[strin
 		cancel()
 	}()
 
+	entry, err := s.client.decodeCreated(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	selections.apply(entry.Values, s.client.selectionFormat)
+
+	return entry, nil
+}
+
+// decodeCreated checks the response to a create request and decodes the
+// created entry, falling back to the Location header for empty bodies.
+func (c *Client) decodeCreated(resp *http.Response) (*Entry, error) {
 	if resp.StatusCode >= http.StatusBadRequest {
-		return nil, s.client.parseAPIError(resp)
+		return nil, c.parseAPIError(resp)
 	}
 
 	var entry Entry
@@ -137,7 +165,8 @@ func (s *entryService) Create(ctx context.Context, form string, values map[strin
 }
 
 // Update modifies an existing entry.
-func (s *entryService) Update(ctx context.Context, form, entryID string, values map[string]any) error {
+// Use WithResult and WithReturnFields to receive the updated entry.
+func (s *entryService) Update(ctx context.Context, form, entryID string, values map[string]any, opts ...WriteOption) error {
 	if form == "" {
 		return ErrEmptyFormName
 	}
@@ -145,6 +174,16 @@ func (s *entryService) Update(ctx context.Context, form, entryID string, values
 		return ErrEmptyEntryID
 	}
 
+	selections, err := s.client.selectionsFor(ctx, form)
+	if err != nil {
+		return err
+	}
+
+	values, err = selections.toValues(values)
+	if err != nil {
+		return err
+	}
+
 	if err := s.client.acquireAndRateLimit(ctx); err != nil {
 		return err
 	}
@@ -152,15 +191,39 @@ func (s *entryService) Update(ctx context.Context, form, entryID string, values
 
 	body := map[string]any{"values": values}
 
-	req, cancel, err := s.client.newJSONRequest(ctx, http.MethodPut, entryIDPath(form, entryID), body)
+	o := buildWriteOptions(opts)
+
+	req, cancel, err := s.client.newJSONRequest(ctx, http.MethodPut, o.path(entryIDPath(form, entryID)), body)
 	if err != nil {
 		return fmt.Errorf("creating update request: %w", err)
 	}
 
-	if err := s.client.doAndDecode(req, cancel, nil); err != nil {
+	return s.updateResult(o, selections, func(target any) error {
+		return s.client.doAndDecode(req, cancel, target)
+	})
+}
+
+// updateResult reads the response of an update with decode and, if
+// WithResult is set, stores the returned entry with its selection values
+// converted.
+func (s *entryService) updateResult(o *writeOptions, selections *SelectionMap, decode func(target any) error) error {
+	if o.result == nil {
+		if err := decode(nil); err != nil {
+			return fmt.Errorf("updating entry: %w", err)
+		}
+		return nil
+	}
+
+	// Servers that do not return the entry respond with 204 No Content,
+	// leaving the result empty.
+	var entry Entry
+	if err := decode(&entry); err != nil && !errors.Is(err, io.EOF) {
 		return fmt.Errorf("updating entry: %w", err)
 	}
 
+	selections.apply(entry.Values, s.client.selectionFormat)
+	*o.result = entry
+
 	return nil
 }
 
@@ -197,17 +260,34 @@ func (s *entryService) Delete(ctx context.Context, form, entryID string, opts ..
 }
 
 // Merge creates or updates an entry based on matching criteria.
-func (s *entryService) Merge(ctx context.Context, form string, values map[string]any) (*Entry, error) {
+// Options control duplicate handling, validation and workflow; invalid
+// combinations are rejected with ErrInvalidMergeOptions before any request.
+func (s *entryService) Merge(ctx context.Context, form string, values map[string]any, opts ...MergeOption) (*Entry, error) {
 	if form == "" {
 		return nil, ErrEmptyFormName
 	}
 
+	o := buildMergeOptions(opts)
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	selections, err := s.client.selectionsFor(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err = selections.toValues(values)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := s.client.acquireAndRateLimit(ctx); err != nil {
 		return nil, err
 	}
 	defer s.client.queue.Release()
 
-	body := map[string]any{"values": values}
+	body := o.body(values)
 	path := apiBasePath + "/mergeEntry/" + url.PathEscape(form)
 
 	req, cancel, err := s.client.newJSONRequest(ctx, http.MethodPost, path, body)
@@ -220,6 +300,8 @@ func (s *entryService) Merge(ctx context.Context, form string, values map[string
 		return nil, fmt.Errorf("merging entry: %w", err)
 	}
 
+	selections.apply(entry.Values, s.client.selectionFormat)
+
 	return &entry, nil
 }
 
@@ -243,3 +325,50 @@ func entryFromLocation(resp *http.Response) (*Entry, bool) {
 
 	return &Entry{Values: map[string]any{"Entry_id": entryID}}, true
 }
+
+// form returns the form named in the entry's self link, or "" if the
+// entry has none.
+func (e *Entry) form() string {
+	for _, link := range e.Links {
+		if link.Rel != "self" {
+			continue
+		}
+		u, err := url.Parse(link.Href)
+		if err != nil {
+			continue
+		}
+		_, rest, ok := strings.Cut(u.EscapedPath(), "/entry/")
+		if !ok {
+			continue
+		}
+		name, _, _ := strings.Cut(rest, "/")
+		if form, err := url.PathUnescape(name); err == nil && form != "" {
+			return form
+		}
+	}
+
+	return ""
+}
+
+// id returns the entry ID from idField, falling back to the last path
+// segment of the entry's self link. It returns "" if neither is available.
+func (e *Entry) id(idField string) string {
+	if id, ok := e.Values[idField].(string); ok && id != "" {
+		return id
+	}
+
+	for _, link := range e.Links {
+		if link.Rel != "self" {
+			continue
+		}
+		u, err := url.Parse(link.Href)
+		if err != nil {
+			continue
+		}
+		if id := path.Base(strings.TrimRight(u.Path, "/")); id != "" && id != "." && id != "/" {
+			return id
+		}
+	}
+
+	return ""
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"maps"
 	"net/http"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -353,3 +355,142 @@ func TestEntryService_Merge_EmptyFormReturnsError(t *testing.T) {
 	require.Error(t, err)
 	assert.ErrorIs(t, err, ErrEmptyFormName)
 }
+
+func TestEntryService_Create_WithReturnFields(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "values(Request ID,Incident Number)", req.URL.Query().Get("fields"))
+
+		return newMockResponse(http.StatusCreated, Entry{Values: map[string]any{
+			"Request ID":      "000000000000123",
+			"Incident Number": "INC000000000123",
+		}}), nil
+	})
+
+	entry, err := client.Entries().Create(t.Context(), "HPD:Help Desk",
+		map[string]any{"Summary": "Test"},
+		WithReturnFields("Request ID", "Incident Number"),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, "INC000000000123", entry.Values["Incident Number"])
+}
+
+func TestEntryService_Update_WithResult(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "values(Status,Assignee)", req.URL.Query().Get("fields"))
+
+		return newMockResponse(http.StatusOK, Entry{Values: map[string]any{
+			"Status":   "Assigned",
+			"Assignee": "Alice",
+		}}), nil
+	})
+
+	var updated Entry
+	err := client.Entries().Update(t.Context(), "HPD:Help Desk", "REQ000001",
+		map[string]any{"Status": "Assigned"},
+		WithReturnFields("Status", "Assignee"),
+		WithResult(&updated),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", updated.Values["Assignee"])
+}
+
+func TestEntryService_Update_WithResultNoContent(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(_ *http.Request) (*http.Response, error) {
+		return newMockResponse(http.StatusNoContent, nil), nil
+	})
+
+	updated := Entry{Values: map[string]any{"stale": true}}
+	err := client.Entries().Update(t.Context(), "Form", "ID",
+		map[string]any{"Status": "Assigned"},
+		WithResult(&updated),
+	)
+
+	require.NoError(t, err)
+	assert.Empty(t, updated.Values)
+}
+
+func TestEntryService_Merge_WithOptions(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+
+		assert.Equal(t, map[string]any{
+			"mergeType":        "DUP_MERGE",
+			"ignoreRequired":   true,
+			"ignorePatterns":   true,
+			"workflowEnabled":  false,
+			"multimatchOption": float64(MultiMatchFirst),
+		}, body["mergeOptions"])
+		assert.Equal(t, `'Serial Number' = "SN-1"`, body["qualification"])
+
+		return newMockResponse(http.StatusOK, Entry{}), nil
+	})
+
+	_, err := client.Entries().Merge(t.Context(), "Form", map[string]any{"Summary": "Test"},
+		WithMergeType(MergeUpdateFields),
+		WithIgnoreRequired(),
+		WithIgnorePatterns(),
+		WithoutWorkflow(),
+		WithMatchQualification(`'Serial Number' = "SN-1"`, MultiMatchFirst),
+	)
+
+	require.NoError(t, err)
+}
+
+func TestEntryService_Merge_WithoutOptionsSendsOnlyValues(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, []string{"values"}, slices.Collect(maps.Keys(body)))
+
+		return newMockResponse(http.StatusOK, Entry{}), nil
+	})
+
+	_, err := client.Entries().Merge(t.Context(), "Form", map[string]any{"Summary": "Test"})
+	require.NoError(t, err)
+}
+
+func TestEntryService_Merge_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []MergeOption
+	}{
+		{
+			name: "unknown merge type",
+			opts: []MergeOption{WithMergeType("DUP_IGNORE")},
+		},
+		{
+			name: "qualification with error on duplicate",
+			opts: []MergeOption{
+				WithMergeType(MergeErrorOnDuplicate),
+				WithMatchQualification(`'A' = 1`, MultiMatchFirst),
+			},
+		},
+		{
+			name: "multi-match without qualification",
+			opts: []MergeOption{
+				WithMergeType(MergeOverwrite),
+				WithMatchQualification("", MultiMatchAll),
+			},
+		},
+		{
+			name: "unknown multi-match option",
+			opts: []MergeOption{
+				WithMergeType(MergeOverwrite),
+				WithMatchQualification(`'A' = 1`, MultiMatchOption(7)),
+			},
+		},
+	}
+
+	client := New("https://remedy.example.com")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Entries().Merge(t.Context(), "Form", map[string]any{"A": 1}, tt.opts...)
+			assert.ErrorIs(t, err, ErrInvalidMergeOptions)
+		})
+	}
+}
@@ -28,6 +28,15 @@ var (
 
 	// ErrEmptyEntryID indicates an entry ID parameter was empty.
 	ErrEmptyEntryID = errors.New("remedy: entry ID cannot be empty")
+
+	// ErrEmptyFieldName indicates a field name parameter was empty.
+	ErrEmptyFieldName = errors.New("remedy: field name cannot be empty")
+
+	// ErrEmptyAssociationName indicates an association name parameter was empty.
+	ErrEmptyAssociationName = errors.New("remedy: association name cannot be empty")
+
+	// ErrInvalidMergeOptions indicates an invalid combination of merge options.
+	ErrInvalidMergeOptions = errors.New("remedy: invalid merge options")
 )
 
 // APIError represents an error returned by the BMC Remedy REST API.
@@ -0,0 +1,564 @@
+package remedy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// exportBuffer is the number of pages buffered per partition in ordered
+// mode and per session in unordered mode.
+const exportBuffer = 2
+
+// ErrInvalidPartitionRange indicates a date range that cannot be split into
+// the requested number of partitions.
+var ErrInvalidPartitionRange = errors.New("remedy: invalid partition range")
+
+// Partition is a subset of a form's entries that is exported independently.
+type Partition struct {
+	// Name identifies the partition in checkpoints and must be unique.
+	Name string
+
+	// Qualification selects the entries of the partition.
+	Qualification string
+}
+
+// DateRangePartitions splits [from, to) into n equal ranges of a date field,
+// such as Create Date. Entries outside the range are not exported.
+// Range boundaries are compared in whole seconds, so adjacent partitions
+// neither overlap nor leave gaps. It returns ErrInvalidPartitionRange if n
+// is not positive, to is not after from, or the ranges would be shorter
+// than a second.
+func DateRangePartitions(field string, from, to time.Time, n int) ([]Partition, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: %d partitions", ErrInvalidPartitionRange, n)
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("%w: %s is not after %s", ErrInvalidPartitionRange,
+			to.Format(time.RFC3339), from.Format(time.RFC3339))
+	}
+
+	step := to.Sub(from) / time.Duration(n)
+	if step < time.Second {
+		return nil, fmt.Errorf("%w: %d partitions of %s are shorter than a second",
+			ErrInvalidPartitionRange, n, to.Sub(from))
+	}
+
+	partitions := make([]Partition, 0, n)
+	for i := range n {
+		start := from.Add(step * time.Duration(i))
+		end := from.Add(step * time.Duration(i+1))
+		if i == n-1 {
+			end = to
+		}
+
+		partitions = append(partitions, Partition{
+			Name: start.UTC().Format(time.RFC3339) + "/" + end.UTC().Format(time.RFC3339),
+			Qualification: NewQuery().
+				And(field, OpGreaterEqual, start.Unix()).
+				And(field, OpLessThan, end.Unix()).
+				Build(),
+		})
+	}
+
+	return partitions, nil
+}
+
+// IDPrefixPartitions creates one partition per prefix of an ID field, such
+// as Request ID. The prefixes should together cover all IDs to export.
+//
+//	remedy.IDPrefixPartitions("Request ID", "INC000000001", "INC000000002", "INC000000003")
+func IDPrefixPartitions(field string, prefixes ...string) []Partition {
+	partitions := make([]Partition, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		partitions = append(partitions, Partition{
+			Name:          prefix,
+			Qualification: NewQuery().And(field, OpLike, prefix+"%").Build(),
+		})
+	}
+
+	return partitions
+}
+
+// ExportOption configures Export.
+type ExportOption func(*exportOptions)
+
+// exportOptions holds the configuration for Export.
+type exportOptions struct {
+	sessions      []EntryServicer
+	query         []QueryOption
+	keyField      string
+	ordered       bool
+	dedup         bool
+	checkpoints   CheckpointStore
+	checkpointKey string
+}
+
+// WithExportSessions fetches partitions over additional logged-in sessions.
+// One partition is fetched at a time per session.
+func WithExportSessions(sessions ...EntryServicer) ExportOption {
+	return func(o *exportOptions) {
+		o.sessions = append(o.sessions, sessions...)
+	}
+}
+
+// WithExportQuery applies query options to every partition. A qualification
+// set with WithQualification is combined with each partition's own, and
+// WithLimit sets the page size.
+func WithExportQuery(opts ...QueryOption) ExportOption {
+	return func(o *exportOptions) {
+		o.query = opts
+	}
+}
+
+// WithExportKeyField sets the unique field used for keyset pagination
+// within partitions, deduplication and checkpoints. The default is the
+// entry ID field (field 1).
+func WithExportKeyField(field string) ExportOption {
+	return func(o *exportOptions) {
+		o.keyField = field
+	}
+}
+
+// WithOrderedExport yields entries partition by partition, in the order the
+// partitions were given, and by key within each partition. Partitions are
+// still fetched concurrently, but a session working ahead of the consumer
+// pauses once its buffer is full. By default entries are yielded as soon as
+// any partition returns them.
+func WithOrderedExport() ExportOption {
+	return func(o *exportOptions) {
+		o.ordered = true
+	}
+}
+
+// WithExportDedup skips entries whose key was already yielded, for
+// partitions that may overlap, such as IDPrefixPartitions with nested
+// prefixes. It keeps every exported key in memory. With
+// WithExportCheckpoint, the keys of each page are saved once, in a separate
+// checkpoint under the export key followed by "/seen/" and a sequence
+// number, so checkpoint writes do not grow with the export. Partitions
+// from DateRangePartitions never overlap and do not need it.
+func WithExportDedup() ExportOption {
+	return func(o *exportOptions) {
+		o.dedup = true
+	}
+}
+
+// WithExportCheckpoint records the progress of each partition in store
+// under key, so a later Export with the same partitions and key skips
+// finished partitions and resumes the others after the last exported key.
+func WithExportCheckpoint(store CheckpointStore, key string) ExportOption {
+	return func(o *exportOptions) {
+		o.checkpoints = store
+		o.checkpointKey = key
+	}
+}
+
+// exportCheckpoint is the persisted state of an export. SeenChunks is the
+// number of saved chunks of yielded keys when deduplication is enabled.
+type exportCheckpoint struct {
+	Partitions map[string]partitionCheckpoint `json:"partitions"`
+	SeenChunks int                            `json:"seenChunks,omitempty"`
+}
+
+// partitionCheckpoint is the persisted state of one partition.
+type partitionCheckpoint struct {
+	Last any  `json:"last,omitempty"`
+	Done bool `json:"done,omitempty"`
+}
+
+// exportPage is a page of entries fetched from a partition.
+type exportPage struct {
+	partition int
+	entries   []Entry
+	last      any
+	done      bool
+	err       error
+}
+
+// Export returns an iterator over the entries of form, fetched partition by
+// partition over the client's session and any sessions added with
+// WithExportSessions, with one partition in flight per session.
+//
+// Example usage:
+//
+//	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+//	partitions, err := remedy.DateRangePartitions("Create Date", from, time.Now(), 32)
+//	...
+//	for entry, err := range remedy.Export(ctx, client.Entries(), "HPD:Help Desk", partitions,
+//	    remedy.WithExportSessions(client2.Entries(), client3.Entries()),
+//	    remedy.WithExportQuery(remedy.WithFields("Request ID", "Status", "Summary")),
+//	    remedy.WithExportCheckpoint(remedy.NewFileCheckpointStore("checkpoints"), "hpd-export"),
+//	) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    write(entry)
+//	}
+//
+// Partitions should not overlap unless WithExportDedup is set. With a
+// checkpoint, progress is saved after each page has been consumed, so after
+// a restart at most the page being consumed is yielded again.
+// Iteration stops at the first error; breaking out of the loop stops all
+// sessions.
+func Export(ctx context.Context, entries EntryServicer, form string, partitions []Partition, opts ...ExportOption) iter.Seq2[*Entry, error] {
+	return func(yield func(*Entry, error) bool) {
+		o, err := buildExportOptions(ctx, entries, form, opts)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		state, err := o.loadCheckpoint(ctx)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		var seen map[string]struct{}
+		if o.dedup {
+			if seen, err = o.loadSeen(ctx, state); err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		var wg sync.WaitGroup
+		defer func() {
+			cancel()
+			wg.Wait()
+		}()
+
+		run := &exportRun{
+			form:       form,
+			partitions: partitions,
+			o:          o,
+			state:      state,
+			seen:       seen,
+		}
+		run.start(ctx, &wg, append([]EntryServicer{entries}, o.sessions...))
+
+		if err := run.consume(ctx, yield); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// buildExportOptions applies export options, defaulting the key field to
+// the entry ID field of form.
+func buildExportOptions(ctx context.Context, entries EntryServicer, form string, opts []ExportOption) (*exportOptions, error) {
+	o := &exportOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.keyField == "" {
+		core, err := coreFieldsOf(ctx, entries, form)
+		if err != nil {
+			return nil, err
+		}
+		o.keyField = core.id
+	}
+
+	return o, nil
+}
+
+// exportRun is the state of a running export.
+type exportRun struct {
+	form       string
+	partitions []Partition
+	o          *exportOptions
+	state      *exportCheckpoint
+
+	// resume holds the checkpoint of each partition at start. Workers read
+	// it while the consumer updates state.
+	resume []partitionCheckpoint
+
+	// outputs holds one channel per partition in ordered mode and a single
+	// shared channel otherwise.
+	outputs []chan exportPage
+
+	// seen holds the keys already yielded, or nil without deduplication.
+	seen map[string]struct{}
+
+	// unsaved holds the keys yielded since the last checkpoint.
+	unsaved []string
+}
+
+// start launches one worker per session.
+func (r *exportRun) start(ctx context.Context, wg *sync.WaitGroup, sessions []EntryServicer) {
+	r.resume = make([]partitionCheckpoint, len(r.partitions))
+	for i, p := range r.partitions {
+		r.resume[i] = r.state.Partitions[p.Name]
+	}
+
+	if r.o.ordered {
+		r.outputs = make([]chan exportPage, len(r.partitions))
+		for i := range r.outputs {
+			r.outputs[i] = make(chan exportPage, exportBuffer)
+		}
+	} else {
+		r.outputs = []chan exportPage{make(chan exportPage, exportBuffer*len(sessions))}
+	}
+
+	next := make(chan int, len(r.partitions))
+	for i := range r.partitions {
+		next <- i
+	}
+	close(next)
+
+	var workers sync.WaitGroup
+	for _, svc := range sessions {
+		workers.Go(func() {
+			for i := range next {
+				r.fetch(ctx, svc, i)
+				if r.o.ordered {
+					close(r.outputs[i])
+				}
+			}
+		})
+	}
+
+	wg.Go(func() {
+		workers.Wait()
+		if !r.o.ordered {
+			close(r.outputs[0])
+		}
+	})
+}
+
+// output returns the channel pages of partition i are sent to.
+func (r *exportRun) output(i int) chan exportPage {
+	if r.o.ordered {
+		return r.outputs[i]
+	}
+
+	return r.outputs[0]
+}
+
+// fetch sends the pages of partition i, resuming from its checkpoint.
+func (r *exportRun) fetch(ctx context.Context, svc EntryServicer, i int) {
+	out := r.output(i)
+	send := func(p exportPage) bool {
+		select {
+		case out <- p:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	cp := r.resume[i]
+	if cp.Done {
+		send(exportPage{partition: i, done: true})
+		return
+	}
+
+	pages := keysetPagesAfter(ctx, svc, r.form, r.o.keyField, keysetKey(cp.Last), r.query(i))
+	for page, err := range pages {
+		if err != nil {
+			send(exportPage{partition: i, err: fmt.Errorf("exporting partition %s: %w", r.partitions[i].Name, err)})
+			return
+		}
+
+		last, _ := keysetValue(&page[len(page)-1], r.o.keyField) // validated by keysetPagesAfter
+		if !send(exportPage{partition: i, entries: page, last: last}) {
+			return
+		}
+	}
+
+	send(exportPage{partition: i, done: true})
+}
+
+// query returns the query options for partition i.
+func (r *exportRun) query(i int) []QueryOption {
+	o := &queryOptions{}
+	for _, opt := range r.o.query {
+		opt(o)
+	}
+
+	q := NewQuery()
+	if o.qualification != "" {
+		q.Raw(o.qualification)
+	}
+	if r.partitions[i].Qualification != "" {
+		q.Raw(r.partitions[i].Qualification)
+	}
+
+	return append(slices.Clone(r.o.query), WithQualification(q.Build()))
+}
+
+// consume yields the entries of all partitions and records their progress.
+func (r *exportRun) consume(ctx context.Context, yield func(*Entry, error) bool) error {
+	for _, out := range r.outputs {
+		for {
+			var page exportPage
+			var ok bool
+			select {
+			case page, ok = <-out:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			if !ok {
+				break
+			}
+
+			stop, err := r.deliver(ctx, page, yield)
+			if err != nil || stop {
+				return err
+			}
+		}
+	}
+
+	return ctx.Err()
+}
+
+// deliver yields the new entries of a page and checkpoints the partition.
+// It reports whether the consumer stopped iterating.
+func (r *exportRun) deliver(ctx context.Context, page exportPage, yield func(*Entry, error) bool) (bool, error) {
+	if page.err != nil {
+		return false, page.err
+	}
+
+	for i := range page.entries {
+		e := &page.entries[i]
+		if r.duplicate(e) {
+			continue
+		}
+
+		if !yield(e, nil) {
+			return true, nil
+		}
+	}
+
+	name := r.partitions[page.partition].Name
+	cp := r.state.Partitions[name]
+	if page.done {
+		cp.Done = true
+	} else {
+		cp.Last = page.last
+	}
+	r.state.Partitions[name] = cp
+
+	if err := r.saveSeen(ctx); err != nil {
+		return false, err
+	}
+
+	return false, r.o.saveCheckpoint(ctx, r.state)
+}
+
+// saveSeen saves the keys yielded since the last checkpoint as a new chunk.
+// Earlier chunks are never rewritten.
+func (r *exportRun) saveSeen(ctx context.Context) error {
+	if r.o.checkpoints == nil || len(r.unsaved) == 0 {
+		return nil
+	}
+
+	data, err := NewIDSet(r.unsaved).MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("encoding exported keys: %w", err)
+	}
+	if err := r.o.checkpoints.Save(ctx, r.o.seenKey(r.state.SeenChunks), data); err != nil {
+		return fmt.Errorf("saving exported keys: %w", err)
+	}
+
+	r.state.SeenChunks++
+	r.unsaved = r.unsaved[:0]
+
+	return nil
+}
+
+// duplicate reports whether the key of e was already yielded and records it.
+func (r *exportRun) duplicate(e *Entry) bool {
+	if r.seen == nil {
+		return false
+	}
+
+	key, _ := keysetValue(e, r.o.keyField)
+	seenKey := fmt.Sprint(key)
+	if _, dup := r.seen[seenKey]; dup {
+		return true
+	}
+	r.seen[seenKey] = struct{}{}
+	if r.o.checkpoints != nil {
+		r.unsaved = append(r.unsaved, seenKey)
+	}
+
+	return false
+}
+
+// loadCheckpoint returns the saved partition progress.
+func (o *exportOptions) loadCheckpoint(ctx context.Context) (*exportCheckpoint, error) {
+	state := &exportCheckpoint{Partitions: make(map[string]partitionCheckpoint)}
+	if o.checkpoints == nil {
+		return state, nil
+	}
+
+	data, err := o.checkpoints.Load(ctx, o.checkpointKey)
+	if errors.Is(err, ErrNoCheckpoint) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading export checkpoint: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("decoding export checkpoint: %w", err)
+	}
+	if state.Partitions == nil {
+		state.Partitions = make(map[string]partitionCheckpoint)
+	}
+
+	return state, nil
+}
+
+// loadSeen returns the keys yielded before the checkpoint was saved.
+func (o *exportOptions) loadSeen(ctx context.Context, state *exportCheckpoint) (map[string]struct{}, error) {
+	seen := make(map[string]struct{})
+	for n := range state.SeenChunks {
+		data, err := o.checkpoints.Load(ctx, o.seenKey(n))
+		if err != nil {
+			return nil, fmt.Errorf("loading exported keys: %w", err)
+		}
+
+		var ids IDSet
+		if err := ids.UnmarshalBinary(data); err != nil {
+			return nil, fmt.Errorf("decoding exported keys: %w", err)
+		}
+		for id := range ids.All() {
+			seen[id] = struct{}{}
+		}
+	}
+
+	return seen, nil
+}
+
+// seenKey returns the checkpoint key of chunk n of the yielded keys.
+func (o *exportOptions) seenKey(n int) string {
+	return o.checkpointKey + "/seen/" + strconv.Itoa(n)
+}
+
+// saveCheckpoint records the partition progress.
+func (o *exportOptions) saveCheckpoint(ctx context.Context, state *exportCheckpoint) error {
+	if o.checkpoints == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encoding export checkpoint: %w", err)
+	}
+
+	if err := o.checkpoints.Save(ctx, o.checkpointKey, data); err != nil {
+		return fmt.Errorf("saving export checkpoint: %w", err)
+	}
+
+	return nil
+}
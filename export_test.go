@@ -0,0 +1,288 @@
+package remedy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	prefixPattern = regexp.MustCompile(`'Request ID' LIKE "([^"]*)%"`)
+	afterPattern  = regexp.MustCompile(`'Request ID' > ("[^"]*")`)
+)
+
+// prefixExportHandler serves keyset pages of sorted ids filtered by the
+// Request ID prefix in the qualification.
+func prefixExportHandler(t *testing.T, ids []string) func(*http.Request) (*http.Response, error) {
+	t.Helper()
+
+	return func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		q := query.Get("q")
+		limit, err := strconv.Atoi(query.Get("limit"))
+		require.NoError(t, err)
+
+		prefix := prefixPattern.FindStringSubmatch(q)
+		require.NotNil(t, prefix, q)
+
+		after := ""
+		if m := afterPattern.FindStringSubmatch(q); m != nil {
+			after, err = strconv.Unquote(m[1])
+			require.NoError(t, err)
+		}
+
+		list := EntryList{}
+		for _, id := range ids {
+			if strings.HasPrefix(id, prefix[1]) && id > after && len(list.Entries) < limit {
+				list.Entries = append(list.Entries, Entry{Values: map[string]any{"Request ID": id}})
+			}
+		}
+
+		return newMockResponse(http.StatusOK, list), nil
+	}
+}
+
+func TestExport_Unordered(t *testing.T) {
+	ids := []string{"A1", "A2", "A3", "B1", "B2", "C1"}
+	primary := setupFormClient(t, nil, prefixExportHandler(t, ids))
+	secondary := setupFormClient(t, nil, prefixExportHandler(t, ids))
+
+	var got []string
+	for entry, err := range Export(t.Context(), primary.Entries(), "Form",
+		IDPrefixPartitions("Request ID", "A", "B", "C"),
+		WithExportSessions(secondary.Entries()),
+		WithExportQuery(WithLimit(2)),
+	) {
+		require.NoError(t, err)
+		got = append(got, entry.Values["Request ID"].(string))
+	}
+
+	slices.Sort(got)
+	assert.Equal(t, ids, got)
+}
+
+func TestExport_Ordered(t *testing.T) {
+	ids := []string{"A1", "A2", "A3", "B1", "B2", "C1"}
+	primary := setupFormClient(t, nil, prefixExportHandler(t, ids))
+	secondary := setupFormClient(t, nil, prefixExportHandler(t, ids))
+
+	var got []string
+	for entry, err := range Export(t.Context(), primary.Entries(), "Form",
+		IDPrefixPartitions("Request ID", "C", "A", "B"),
+		WithExportSessions(secondary.Entries()),
+		WithExportQuery(WithLimit(2)),
+		WithOrderedExport(),
+	) {
+		require.NoError(t, err)
+		got = append(got, entry.Values["Request ID"].(string))
+	}
+
+	assert.Equal(t, []string{"C1", "A1", "A2", "A3", "B1", "B2"}, got)
+}
+
+func TestExport_Deduplicates(t *testing.T) {
+	ids := []string{"A1", "A2", "A3"}
+	client := setupFormClient(t, nil, prefixExportHandler(t, ids))
+
+	var got []string
+	for entry, err := range Export(t.Context(), client.Entries(), "Form",
+		IDPrefixPartitions("Request ID", "A", "A2"), WithOrderedExport(), WithExportDedup()) {
+		require.NoError(t, err)
+		got = append(got, entry.Values["Request ID"].(string))
+	}
+
+	assert.Equal(t, ids, got)
+}
+
+func TestExport_ResumesFromCheckpoint(t *testing.T) {
+	ids := []string{"A1", "A2", "A3", "B1", "B2"}
+	client := setupFormClient(t, nil, prefixExportHandler(t, ids))
+	store := NewMemoryCheckpointStore()
+	partitions := IDPrefixPartitions("Request ID", "A", "B")
+
+	export := func(limit int) []string {
+		var got []string
+		for entry, err := range Export(t.Context(), client.Entries(), "Form", partitions,
+			WithExportQuery(WithLimit(2)),
+			WithOrderedExport(),
+			WithExportCheckpoint(store, "export"),
+		) {
+			require.NoError(t, err)
+			got = append(got, entry.Values["Request ID"].(string))
+			if len(got) == limit {
+				break
+			}
+		}
+		return got
+	}
+
+	assert.Equal(t, []string{"A1", "A2", "A3"}, export(3))
+
+	// The page holding A3 was not fully consumed, so it is yielded again.
+	assert.Equal(t, []string{"A3", "B1", "B2"}, export(-1))
+	assert.Empty(t, export(-1))
+}
+
+func TestExport_CombinesQualification(t *testing.T) {
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, `('Status' = "New") AND ('Request ID' LIKE "A%")`, req.URL.Query().Get("q"))
+		return newMockResponse(http.StatusOK, EntryList{}), nil
+	})
+
+	for _, err := range Export(t.Context(), client.Entries(), "Form",
+		IDPrefixPartitions("Request ID", "A"),
+		WithExportQuery(WithQualification(`'Status' = "New"`))) {
+		require.NoError(t, err)
+	}
+}
+
+func TestExport_Error(t *testing.T) {
+	client := setupFormClient(t, nil, func(_ *http.Request) (*http.Response, error) {
+		return newMockResponse(http.StatusInternalServerError, []apiErrorResponse{
+			{MessageType: "ERROR", MessageText: "Server busy", MessageNumber: 9093},
+		}), nil
+	})
+
+	var errs []error
+	for _, err := range Export(t.Context(), client.Entries(), "Form", IDPrefixPartitions("Request ID", "A", "B")) {
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 1)
+	var apiErr *APIError
+	require.ErrorAs(t, errs[0], &apiErr)
+	assert.Equal(t, 9093, apiErr.MessageNumber)
+}
+
+func TestDateRangePartitions(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(48 * time.Hour)
+
+	partitions, err := DateRangePartitions("Create Date", from, to, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Partition{
+		{
+			Name:          "2024-01-01T00:00:00Z/2024-01-02T00:00:00Z",
+			Qualification: `'Create Date' >= 1704067200 AND 'Create Date' < 1704153600`,
+		},
+		{
+			Name:          "2024-01-02T00:00:00Z/2024-01-03T00:00:00Z",
+			Qualification: `'Create Date' >= 1704153600 AND 'Create Date' < 1704240000`,
+		},
+	}, partitions)
+}
+
+func TestDateRangePartitions_Invalid(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		to   time.Time
+		n    int
+	}{
+		{name: "no partitions", to: from.Add(time.Hour), n: 0},
+		{name: "negative partitions", to: from.Add(time.Hour), n: -1},
+		{name: "empty range", to: from, n: 2},
+		{name: "reversed range", to: from.Add(-time.Hour), n: 2},
+		{name: "sub-second partitions", to: from.Add(time.Second), n: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DateRangePartitions("Create Date", from, tt.to, tt.n)
+			require.ErrorIs(t, err, ErrInvalidPartitionRange)
+		})
+	}
+}
+
+func TestExport_DeduplicatesAfterResume(t *testing.T) {
+	ids := []string{"A1", "A2", "A3"}
+	client := setupFormClient(t, nil, prefixExportHandler(t, ids))
+	store := NewMemoryCheckpointStore()
+	partitions := IDPrefixPartitions("Request ID", "A2", "A")
+
+	export := func(limit int) []string {
+		var got []string
+		for entry, err := range Export(t.Context(), client.Entries(), "Form", partitions,
+			WithExportQuery(WithLimit(1)),
+			WithOrderedExport(),
+			WithExportDedup(),
+			WithExportCheckpoint(store, "export"),
+		) {
+			require.NoError(t, err)
+			got = append(got, entry.Values["Request ID"].(string))
+			if len(got) == limit {
+				break
+			}
+		}
+		return got
+	}
+
+	// Partition A2 is finished and checkpointed; A stops after its first entry.
+	assert.Equal(t, []string{"A2", "A1"}, export(2))
+
+	// A restarts, and A2 is still known to have been yielded.
+	assert.Equal(t, []string{"A1", "A3"}, export(-1))
+}
+
+func TestExport_WithoutDedup(t *testing.T) {
+	ids := []string{"A1", "A2", "A3"}
+	client := setupFormClient(t, nil, prefixExportHandler(t, ids))
+
+	var got []string
+	for entry, err := range Export(t.Context(), client.Entries(), "Form",
+		IDPrefixPartitions("Request ID", "A", "A2"), WithOrderedExport()) {
+		require.NoError(t, err)
+		got = append(got, entry.Values["Request ID"].(string))
+	}
+
+	assert.Equal(t, []string{"A1", "A2", "A3", "A2"}, got)
+}
+
+// sizeCheckpointStore records the size of every checkpoint saved.
+type sizeCheckpointStore struct {
+	*MemoryCheckpointStore
+	sizes []int
+}
+
+// Save implements CheckpointStore.
+func (s *sizeCheckpointStore) Save(ctx context.Context, key string, data []byte) error {
+	s.sizes = append(s.sizes, len(data))
+	return s.MemoryCheckpointStore.Save(ctx, key, data)
+}
+
+func TestExport_DedupCheckpointSizeIsBounded(t *testing.T) {
+	ids := make([]string, 500)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("A%06d", i)
+	}
+	client := setupFormClient(t, nil, prefixExportHandler(t, ids))
+	store := &sizeCheckpointStore{MemoryCheckpointStore: NewMemoryCheckpointStore()}
+
+	n := 0
+	for _, err := range Export(t.Context(), client.Entries(), "Form",
+		IDPrefixPartitions("Request ID", "A"),
+		WithExportQuery(WithLimit(5)),
+		WithExportDedup(),
+		WithExportCheckpoint(store, "export"),
+	) {
+		require.NoError(t, err)
+		n++
+	}
+	require.Equal(t, len(ids), n)
+
+	// Each page saves its own keys and the partition progress, so no save
+	// grows with the number of exported entries.
+	assert.Len(t, store.sizes, 2*len(ids)/5+1)
+	assert.Less(t, slices.Max(store.sizes), 100)
+}
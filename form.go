@@ -0,0 +1,179 @@
+package remedy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// IDs of the core fields present on every form.
+const (
+	coreFieldID           = 1
+	coreFieldCreateDate   = 3
+	coreFieldModifiedDate = 6
+)
+
+// coreFields holds the names of the core fields of a form. Forms created
+// in Developer Studio use the defaultCoreFields names; ITSM forms rename
+// them, for example to Entry ID, Submit Date and Last Modified Date on
+// HPD:Help Desk.
+type coreFields struct {
+	id       string
+	created  string
+	modified string
+}
+
+// defaultCoreFields are the core field names of a new form, used when
+// metadata is not available.
+var defaultCoreFields = coreFields{
+	id:       "Request ID",
+	created:  "Create Date",
+	modified: "Modified Date",
+}
+
+// formService implements FormServicer for form metadata operations.
+type formService struct {
+	client *Client
+
+	// metadata caches field definitions and selections caches selection
+	// maps per form; metadata rarely changes and is needed on every read
+	// and write when mapping is enabled.
+	metadata   map[string][]Field
+	selections map[string]*SelectionMap
+	cacheMu    sync.Mutex
+}
+
+// Fields retrieves the field definitions of a form.
+func (s *formService) Fields(ctx context.Context, form string) ([]Field, error) {
+	if form == "" {
+		return nil, ErrEmptyFormName
+	}
+
+	if err := s.client.acquireAndRateLimit(ctx); err != nil {
+		return nil, err
+	}
+	defer s.client.queue.Release()
+
+	req, cancel, err := s.client.newJSONRequest(ctx, http.MethodGet, fieldsPath(form), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating fields request: %w", err)
+	}
+
+	var fields []Field
+	if err := s.client.doAndDecode(req, cancel, &fields); err != nil {
+		return nil, fmt.Errorf("getting fields: %w", err)
+	}
+
+	return fields, nil
+}
+
+// Selections returns the selection map of a form.
+// The map is built from the form's field metadata on first use and cached
+// for the lifetime of the client.
+func (s *formService) Selections(ctx context.Context, form string) (*SelectionMap, error) {
+	s.cacheMu.Lock()
+	m, ok := s.selections[form]
+	s.cacheMu.Unlock()
+
+	if ok {
+		return m, nil
+	}
+
+	fields, err := s.cachedFields(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+	m = NewSelectionMap(fields)
+
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	if s.selections == nil {
+		s.selections = make(map[string]*SelectionMap)
+	}
+	s.selections[form] = m
+
+	return m, nil
+}
+
+// cachedSelections returns the selection map of form if it has already
+// been loaded. It performs no request, so it can be called while holding
+// the request queue.
+func (s *formService) cachedSelections(form string) (*SelectionMap, bool) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	m, ok := s.selections[form]
+
+	return m, ok
+}
+
+// coreFields returns the core field names of form from its cached
+// metadata. Core fields missing from the metadata keep their default names.
+func (s *formService) coreFields(ctx context.Context, form string) (coreFields, error) {
+	fields, err := s.cachedFields(ctx, form)
+	if err != nil {
+		return coreFields{}, fmt.Errorf("loading core fields: %w", err)
+	}
+
+	names := defaultCoreFields
+	for _, f := range fields {
+		switch f.ID {
+		case coreFieldID:
+			names.id = f.Name
+		case coreFieldCreateDate:
+			names.created = f.Name
+		case coreFieldModifiedDate:
+			names.modified = f.Name
+		}
+	}
+
+	return names, nil
+}
+
+// cachedFields returns the field definitions of form, fetching them on
+// first use.
+func (s *formService) cachedFields(ctx context.Context, form string) ([]Field, error) {
+	s.cacheMu.Lock()
+	fields, ok := s.metadata[form]
+	s.cacheMu.Unlock()
+
+	if ok {
+		return fields, nil
+	}
+
+	fields, err := s.Fields(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	if s.metadata == nil {
+		s.metadata = make(map[string][]Field)
+	}
+	s.metadata[form] = fields
+
+	return fields, nil
+}
+
+// coreFieldsOf returns the core field names of form. Entry services of a
+// Client read them from form metadata, which performs a request on first
+// use, so it must be called before acquiring the request queue. Other
+// EntryServicer implementations, such as mocks, get the default names.
+func coreFieldsOf(ctx context.Context, entries EntryServicer, form string) (coreFields, error) {
+	svc, ok := entries.(*entryService)
+	if !ok {
+		return defaultCoreFields, nil
+	}
+
+	return svc.client.forms.coreFields(ctx, form)
+}
+
+// fieldsPath builds the API path for form field metadata.
+func fieldsPath(form string) string {
+	return apiBasePath + "/fields/" + url.PathEscape(form)
+}
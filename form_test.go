@@ -0,0 +1,113 @@
+package remedy
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testStatusField is selection metadata shared by form and selection tests.
+var testStatusField = Field{
+	ID:       7,
+	Name:     "Status",
+	DataType: DataTypeSelection,
+	SelectionValues: []SelectionValue{
+		{Value: 0, Label: "New"},
+		{Value: 1, Label: "Assigned"},
+		{Value: 4, Label: "Closed"},
+	},
+}
+
+// itsmCoreFields is the core field metadata of ITSM forms such as HPD:Help Desk.
+var itsmCoreFields = []Field{
+	{ID: 1, Name: "Entry ID", DataType: DataTypeCharacter},
+	{ID: 3, Name: "Submit Date", DataType: DataTypeDateTime},
+	{ID: 6, Name: "Last Modified Date", DataType: DataTypeDateTime},
+}
+
+// setupFormClient returns an authenticated client that answers form
+// metadata requests with fields and passes other requests to doFunc.
+func setupFormClient(t *testing.T, fields []Field, doFunc func(*http.Request) (*http.Response, error)) *Client {
+	t.Helper()
+
+	return setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		if strings.HasPrefix(req.URL.Path, "/api/arsys/v1/fields/") {
+			return newMockResponse(http.StatusOK, fields), nil
+		}
+		return doFunc(req)
+	})
+}
+
+func TestFormService_Fields(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "/api/arsys/v1/fields/HPD:Help%20Desk", req.URL.EscapedPath())
+
+		return newMockResponse(http.StatusOK, []Field{
+			{ID: 1, Name: "Request ID", DataType: DataTypeCharacter},
+			testStatusField,
+		}), nil
+	})
+
+	fields, err := client.Forms().Fields(t.Context(), "HPD:Help Desk")
+
+	require.NoError(t, err)
+	require.Len(t, fields, 2)
+	assert.Equal(t, "Status", fields[1].Name)
+	assert.Len(t, fields[1].SelectionValues, 3)
+}
+
+func TestFormService_Fields_EmptyFormReturnsError(t *testing.T) {
+	client := New("https://remedy.example.com")
+
+	_, err := client.Forms().Fields(t.Context(), "")
+
+	assert.ErrorIs(t, err, ErrEmptyFormName)
+}
+
+func TestFormService_Selections_Cached(t *testing.T) {
+	calls := 0
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		assert.True(t, strings.HasPrefix(req.URL.Path, "/api/arsys/v1/fields/"))
+		calls++
+
+		return newMockResponse(http.StatusOK, []Field{testStatusField}), nil
+	})
+
+	first, err := client.Forms().Selections(t.Context(), "Form")
+	require.NoError(t, err)
+	second, err := client.Forms().Selections(t.Context(), "Form")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, calls, "metadata should be fetched once per form")
+	assert.Same(t, first, second)
+	assert.True(t, first.IsSelection("Status"))
+}
+
+func TestFormService_CoreFields(t *testing.T) {
+	calls := 0
+	client := setupAuthenticatedClient(t, func(_ *http.Request) (*http.Response, error) {
+		calls++
+		return newMockResponse(http.StatusOK, append([]Field{testStatusField}, itsmCoreFields...)), nil
+	})
+
+	core, err := client.forms.coreFields(t.Context(), "HPD:Help Desk")
+	require.NoError(t, err)
+	assert.Equal(t, coreFields{id: "Entry ID", created: "Submit Date", modified: "Last Modified Date"}, core)
+
+	_, err = client.Forms().Selections(t.Context(), "HPD:Help Desk")
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls, "metadata should be fetched once per form")
+}
+
+func TestFormService_CoreFields_Defaults(t *testing.T) {
+	client := setupFormClient(t, []Field{testStatusField}, nil)
+
+	core, err := client.forms.coreFields(t.Context(), "Form")
+
+	require.NoError(t, err)
+	assert.Equal(t, defaultCoreFields, core)
+}
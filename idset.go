@@ -0,0 +1,195 @@
+package remedy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// idSetMagic identifies the binary encoding of an IDSet, followed by a
+// version byte.
+var idSetMagic = []byte("RIDS")
+
+// idSetVersion is the version of the binary encoding.
+const idSetVersion = 1
+
+// ErrInvalidIDSet indicates binary data that is not a valid encoded IDSet.
+var ErrInvalidIDSet = errors.New("remedy: invalid ID set")
+
+// IDSet is an immutable sorted set of entry IDs stored with front coding:
+// each ID is encoded as the length of the prefix it shares with the
+// previous ID followed by the remaining bytes. Sequential IDs such as
+// "INC000000123456" then take a few bytes each, so sets of millions of
+// IDs fit comfortably in memory and on disk.
+type IDSet struct {
+	data []byte
+	n    int
+}
+
+// NewIDSet creates a set from ids in any order. Duplicates are removed.
+func NewIDSet(ids []string) *IDSet {
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+
+	var b IDSetBuilder
+	for _, id := range slices.Compact(sorted) {
+		b.Add(id)
+	}
+
+	return b.Build()
+}
+
+// Len returns the number of IDs in the set.
+func (s *IDSet) Len() int {
+	return s.n
+}
+
+// All returns an iterator over the IDs in ascending byte order.
+func (s *IDSet) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		var prev []byte
+		for data := s.data; len(data) > 0; {
+			var err error
+			prev, data, err = decodeID(prev, data)
+			if err != nil || !yield(string(prev)) {
+				return
+			}
+		}
+	}
+}
+
+// Difference returns an iterator over the IDs in s that are not in other,
+// in ascending order.
+func (s *IDSet) Difference(other *IDSet) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		next, stop := iter.Pull(other.All())
+		defer stop()
+
+		o, ok := next()
+		for id := range s.All() {
+			for ok && o < id {
+				o, ok = next()
+			}
+			if ok && o == id {
+				continue
+			}
+			if !yield(id) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s *IDSet) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, len(idSetMagic)+1+binary.MaxVarintLen64+len(s.data))
+	buf = append(buf, idSetMagic...)
+	buf = append(buf, idSetVersion)
+	buf = binary.AppendUvarint(buf, uint64(s.n))
+
+	return append(buf, s.data...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *IDSet) UnmarshalBinary(data []byte) error {
+	if !bytes.HasPrefix(data, idSetMagic) || len(data) < len(idSetMagic)+1 {
+		return fmt.Errorf("%w: missing header", ErrInvalidIDSet)
+	}
+	if v := data[len(idSetMagic)]; v != idSetVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidIDSet, v)
+	}
+	data = data[len(idSetMagic)+1:]
+
+	n, size := binary.Uvarint(data)
+	if size <= 0 {
+		return fmt.Errorf("%w: bad count", ErrInvalidIDSet)
+	}
+	data = data[size:]
+
+	// validate the entries before accepting them
+	var prev []byte
+	count := 0
+	for rest := data; len(rest) > 0; count++ {
+		var err error
+		if prev, rest, err = decodeID(prev, rest); err != nil {
+			return err
+		}
+	}
+	if uint64(count) != n {
+		return fmt.Errorf("%w: expected %d IDs, found %d", ErrInvalidIDSet, n, count)
+	}
+
+	s.data = slices.Clone(data)
+	s.n = count
+
+	return nil
+}
+
+// IDSetBuilder builds an IDSet incrementally. The zero value is ready to use.
+// IDs added in ascending byte order are encoded as they arrive; if an ID
+// arrives out of order, the builder falls back to sorting on Build.
+type IDSetBuilder struct {
+	set      IDSet
+	last     string
+	unsorted []string
+}
+
+// Add adds an ID to the set.
+func (b *IDSetBuilder) Add(id string) {
+	switch {
+	case b.unsorted != nil:
+		b.unsorted = append(b.unsorted, id)
+	case b.set.n == 0 || id > b.last:
+		b.set.data = encodeID(b.set.data, b.last, id)
+		b.set.n++
+		b.last = id
+	case id != b.last:
+		b.unsorted = append(slices.Collect(b.set.All()), id)
+	}
+}
+
+// Build returns the set of added IDs. The builder must not be used afterwards.
+func (b *IDSetBuilder) Build() *IDSet {
+	if b.unsorted != nil {
+		return NewIDSet(b.unsorted)
+	}
+
+	return &b.set
+}
+
+// encodeID appends id to buf, front-coded against prev.
+func encodeID(buf []byte, prev, id string) []byte {
+	shared := 0
+	for shared < len(prev) && shared < len(id) && prev[shared] == id[shared] {
+		shared++
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(shared))
+	buf = binary.AppendUvarint(buf, uint64(len(id)-shared))
+
+	return append(buf, id[shared:]...)
+}
+
+// decodeID decodes the ID at the start of data, front-coded against prev,
+// and returns it with the remaining data. The returned ID reuses prev's
+// storage when possible.
+func decodeID(prev, data []byte) (id, rest []byte, err error) {
+	shared, n := binary.Uvarint(data)
+	if n <= 0 || shared > uint64(len(prev)) {
+		return nil, nil, fmt.Errorf("%w: bad prefix length", ErrInvalidIDSet)
+	}
+	data = data[n:]
+
+	suffix, n := binary.Uvarint(data)
+	if n <= 0 || suffix > uint64(len(data)-n) {
+		return nil, nil, fmt.Errorf("%w: bad suffix length", ErrInvalidIDSet)
+	}
+	data = data[n:]
+
+	id = append(prev[:shared], data[:suffix]...)
+
+	return id, data[suffix:], nil
+}
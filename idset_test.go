@@ -0,0 +1,79 @@
+package remedy
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIDSet(t *testing.T) {
+	set := NewIDSet([]string{"INC0003", "INC0001", "INC0002", "INC0001", "CHG1"})
+
+	assert.Equal(t, 4, set.Len())
+	assert.Equal(t, []string{"CHG1", "INC0001", "INC0002", "INC0003"}, slices.Collect(set.All()))
+}
+
+func TestIDSetBuilder_OutOfOrder(t *testing.T) {
+	var b IDSetBuilder
+	for _, id := range []string{"A1", "A2", "A2", "A0", "B1"} {
+		b.Add(id)
+	}
+
+	set := b.Build()
+
+	assert.Equal(t, []string{"A0", "A1", "A2", "B1"}, slices.Collect(set.All()))
+}
+
+func TestIDSet_Difference(t *testing.T) {
+	old := NewIDSet([]string{"A", "B", "C", "D", "F"})
+	current := NewIDSet([]string{"B", "D", "E"})
+
+	assert.Equal(t, []string{"A", "C", "F"}, slices.Collect(old.Difference(current)))
+	assert.Equal(t, []string{"E"}, slices.Collect(current.Difference(old)))
+	assert.Empty(t, slices.Collect(old.Difference(old)))
+}
+
+func TestIDSet_MarshalBinary(t *testing.T) {
+	ids := make([]string, 0, 10000)
+	for i := range 10000 {
+		ids = append(ids, fmt.Sprintf("INC%012d", i))
+	}
+	set := NewIDSet(ids)
+
+	data, err := set.MarshalBinary()
+	require.NoError(t, err)
+
+	// front coding stores sequential IDs in a few bytes each
+	assert.Less(t, len(data), 10000*6)
+
+	var decoded IDSet
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, 10000, decoded.Len())
+	assert.Equal(t, ids, slices.Collect(decoded.All()))
+}
+
+func TestIDSet_UnmarshalBinary_Invalid(t *testing.T) {
+	valid, err := NewIDSet([]string{"REQ1", "REQ2"}).MarshalBinary()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"wrong magic", []byte("XXXX\x01\x00")},
+		{"wrong version", []byte("RIDS\x02\x00")},
+		{"truncated", valid[:len(valid)-1]},
+		{"wrong count", append([]byte("RIDS\x01\x05"), valid[6:]...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var set IDSet
+			assert.ErrorIs(t, set.UnmarshalBinary(tt.data), ErrInvalidIDSet)
+		})
+	}
+}
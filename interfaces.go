@@ -3,6 +3,7 @@ package remedy
 import (
 	"context"
 	"io"
+	"iter"
 	"net/http"
 )
 
@@ -21,27 +22,58 @@ type EntryServicer interface {
 	// List retrieves multiple entries with optional filtering and pagination.
 	List(ctx context.Context, form string, opts ...QueryOption) (*EntryList, error)
 
+	// Stream lists entries, decoding and yielding them one at a time.
+	Stream(ctx context.Context, form string, opts ...QueryOption) iter.Seq2[*Entry, error]
+
 	// Create creates a new entry in the specified form.
-	Create(ctx context.Context, form string, values map[string]any) (*Entry, error)
+	Create(ctx context.Context, form string, values map[string]any, opts ...WriteOption) (*Entry, error)
 
 	// Update updates an existing entry.
-	Update(ctx context.Context, form, entryID string, values map[string]any) error
+	Update(ctx context.Context, form, entryID string, values map[string]any, opts ...WriteOption) error
 
 	// Delete removes an entry.
 	Delete(ctx context.Context, form, entryID string, opts ...DeleteOption) error
 
 	// Merge creates or updates an entry based on matching criteria.
-	Merge(ctx context.Context, form string, values map[string]any) (*Entry, error)
+	Merge(ctx context.Context, form string, values map[string]any, opts ...MergeOption) (*Entry, error)
+
+	// Associations retrieves the entries related to an entry through an association.
+	Associations(ctx context.Context, form, entryID, association string, opts ...QueryOption) (*EntryList, error)
+
+	// CreateWithAttachments creates an entry with attachments in a single request.
+	CreateWithAttachments(ctx context.Context, form string, values map[string]any, attachments map[string]AttachmentUpload, opts ...WriteOption) (*Entry, error)
+
+	// UpdateWithAttachments updates an entry and its attachments in a single request.
+	UpdateWithAttachments(ctx context.Context, form, entryID string, values map[string]any, attachments map[string]AttachmentUpload, opts ...WriteOption) error
 }
 
 // AttachmentServicer defines attachment operations for the Remedy API.
 // This interface enables mocking the attachment service in tests.
 type AttachmentServicer interface {
-	// Get retrieves an attachment from an entry.
-	Get(ctx context.Context, form, entryID, fieldName string) (io.ReadCloser, error)
+	// Get retrieves an attachment from an entry with its name, size and content type.
+	Get(ctx context.Context, form, entryID, fieldName string) (*Attachment, error)
 
 	// Upload uploads an attachment to an entry.
-	Upload(ctx context.Context, form, entryID, fieldName, filename string, data io.Reader) error
+	Upload(ctx context.Context, form, entryID, fieldName, filename string, data io.Reader, opts ...UploadOption) error
+
+	// Delete removes the attachment from an entry field.
+	Delete(ctx context.Context, form, entryID, fieldName string) error
+
+	// List returns the attachment fields of an entry with their names and sizes.
+	List(ctx context.Context, form, entryID string) ([]AttachmentField, error)
+
+	// Download writes an attachment to a file, resuming interrupted transfers.
+	Download(ctx context.Context, form, entryID, fieldName, path string, opts ...DownloadOption) (*DownloadResult, error)
+}
+
+// FormServicer defines form metadata operations for the Remedy API.
+// This interface enables mocking the form service in tests.
+type FormServicer interface {
+	// Fields retrieves the field definitions of a form.
+	Fields(ctx context.Context, form string) ([]Field, error)
+
+	// Selections returns the cached selection map of a form.
+	Selections(ctx context.Context, form string) (*SelectionMap, error)
 }
 
 // RemedyClient defines the full client interface for the Remedy API.
@@ -61,4 +93,7 @@ type RemedyClient interface {
 
 	// Attachments returns the attachment service.
 	Attachments() AttachmentServicer
+
+	// Forms returns the form metadata service.
+	Forms() FormServicer
 }
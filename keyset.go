@@ -0,0 +1,134 @@
+package remedy
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+)
+
+// KeysetPages returns an iterator over pages of entries matching opts,
+// using keyset (seek) pagination instead of offsets.
+//
+// Entries are sorted by keyField, which must be unique and stable, such as
+// Request ID (field 1). Each page after the first is requested with
+// `(<qualification>) AND '<keyField>' > "<last key>"`, so entries created
+// or deleted during a long export do not shift later pages and cause
+// duplicates or gaps as they do with WithOffset.
+//
+// The qualification set with WithQualification is combined with the key
+// condition. WithLimit sets the page size (default 1000); WithSort and
+// WithOffset are ignored. keyField is added to WithFields if missing.
+//
+// Example usage:
+//
+//	q := remedy.NewQuery().And("Status", "=", "Closed").Build()
+//	for page, err := range remedy.KeysetPages(ctx, client.Entries(), "HPD:Help Desk",
+//	    "Request ID", remedy.WithQualification(q), remedy.WithLimit(500)) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    export(page)
+//	}
+//
+// Iteration stops after the first error.
+func KeysetPages(ctx context.Context, entries EntryServicer, form, keyField string, opts ...QueryOption) iter.Seq2[[]Entry, error] {
+	return keysetPagesAfter(ctx, entries, form, keyField, nil, opts)
+}
+
+// keysetPagesAfter is KeysetPages starting after the given key, or from
+// the first entry if after is nil.
+func keysetPagesAfter(ctx context.Context, entries EntryServicer, form, keyField string, after any, opts []QueryOption) iter.Seq2[[]Entry, error] {
+	return func(yield func([]Entry, error) bool) {
+		if keyField == "" {
+			yield(nil, ErrEmptyFieldName)
+			return
+		}
+
+		o := &queryOptions{}
+		for _, opt := range opts {
+			opt(o)
+		}
+
+		pageSize := o.limit
+		if pageSize <= 0 {
+			pageSize = defaultScanPageSize
+		}
+
+		fields := o.fields
+		if len(fields) > 0 && !slices.Contains(fields, keyField) {
+			fields = append(slices.Clone(fields), keyField)
+		}
+
+		last := after
+		for {
+			qualification := o.qualification
+			if last != nil {
+				qualification = keysetQualification(o.qualification, keyField, last)
+			}
+
+			pageOpts := append(slices.Clone(opts),
+				WithQualification(qualification),
+				WithFields(fields...),
+				WithSort(keyField, SortAsc),
+				WithLimit(pageSize),
+				WithOffset(0),
+			)
+
+			list, err := entries.List(ctx, form, pageOpts...)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if len(list.Entries) == 0 {
+				return
+			}
+
+			last, err = keysetValue(&list.Entries[len(list.Entries)-1], keyField)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(list.Entries, nil) || len(list.Entries) < pageSize {
+				return
+			}
+		}
+	}
+}
+
+// keysetQualification restricts qualification to entries after last.
+func keysetQualification(qualification, keyField string, last any) string {
+	q := NewQuery()
+	if qualification != "" {
+		q.Raw(qualification)
+	}
+
+	return q.And(keyField, OpGreaterThan, last).Build()
+}
+
+// keysetValue returns the key of an entry for use in the next page's
+// qualification.
+func keysetValue(e *Entry, keyField string) (any, error) {
+	v := e.Values[keyField]
+	if v == nil {
+		if id := e.id(keyField); id != "" {
+			return id, nil
+		}
+		return nil, fmt.Errorf("entry has no %q value for keyset pagination", keyField)
+	}
+
+	return keysetKey(v), nil
+}
+
+// keysetKey converts whole numbers decoded from JSON to int64 so they are
+// not formatted in exponent notation.
+func keysetKey(v any) any {
+	if f, ok := v.(float64); ok && f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		return int64(f)
+	}
+
+	return v
+}
@@ -0,0 +1,96 @@
+package remedy
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeysetPages(t *testing.T) {
+	pages := [][]Entry{
+		{{Values: map[string]any{"Request ID": "REQ1"}}, {Values: map[string]any{"Request ID": "REQ2"}}},
+		{{Values: map[string]any{"Request ID": "REQ3"}}},
+	}
+
+	var queries []string
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		assert.Equal(t, "Request ID", query.Get("sort"))
+		assert.Equal(t, "2", query.Get("limit"))
+		assert.Empty(t, query.Get("offset"))
+		assert.Equal(t, "values(Summary,Request ID)", query.Get("fields"))
+
+		queries = append(queries, query.Get("q"))
+		return newMockResponse(http.StatusOK, EntryList{Entries: pages[len(queries)-1]}), nil
+	})
+
+	q := NewQuery().And("Status", "=", "New").Or("Status", "=", "Assigned").Build()
+
+	var ids []string
+	for page, err := range KeysetPages(t.Context(), client.Entries(), "Form", "Request ID",
+		WithQualification(q), WithFields("Summary"), WithLimit(2), WithOffset(10), WithSort("Summary", SortDesc)) {
+		require.NoError(t, err)
+		for _, e := range page {
+			ids = append(ids, e.Values["Request ID"].(string))
+		}
+	}
+
+	assert.Equal(t, []string{"REQ1", "REQ2", "REQ3"}, ids)
+	assert.Equal(t, []string{
+		`'Status' = "New" OR 'Status' = "Assigned"`,
+		`('Status' = "New" OR 'Status' = "Assigned") AND 'Request ID' > "REQ2"`,
+	}, queries)
+}
+
+func TestKeysetPages_NumericKey(t *testing.T) {
+	var queries []string
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		queries = append(queries, req.URL.Query().Get("q"))
+		if len(queries) > 1 {
+			return newMockResponse(http.StatusOK, EntryList{}), nil
+		}
+		return newMockResponse(http.StatusOK, EntryList{Entries: []Entry{
+			{Values: map[string]any{"Sequence": 1234567}},
+		}}), nil
+	})
+
+	for _, err := range KeysetPages(t.Context(), client.Entries(), "Form", "Sequence", WithLimit(1)) {
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"", `'Sequence' > 1234567`}, queries)
+}
+
+func TestKeysetPages_MissingKey(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(_ *http.Request) (*http.Response, error) {
+		return newMockResponse(http.StatusOK, EntryList{Entries: []Entry{
+			{Values: map[string]any{"Summary": "no key"}},
+		}}), nil
+	})
+
+	var errs []error
+	for _, err := range KeysetPages(t.Context(), client.Entries(), "Form", "Sequence") {
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], `"Sequence"`)
+}
+
+func TestKeysetPages_Break(t *testing.T) {
+	requests := 0
+	client := setupAuthenticatedClient(t, func(_ *http.Request) (*http.Response, error) {
+		requests++
+		return newMockResponse(http.StatusOK, EntryList{Entries: []Entry{
+			{Values: map[string]any{"Request ID": "REQ1"}},
+		}}), nil
+	})
+
+	for range KeysetPages(t.Context(), client.Entries(), "Form", "Request ID", WithLimit(1)) {
+		break
+	}
+
+	assert.Equal(t, 1, requests)
+}
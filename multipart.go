@@ -0,0 +1,224 @@
+package remedy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"slices"
+)
+
+// AttachmentUpload is a file sent with CreateWithAttachments or
+// UpdateWithAttachments.
+type AttachmentUpload struct {
+	// Filename is the file name stored in the attachment field.
+	Filename string
+
+	// Data is the file contents. It is read once and not closed.
+	Data io.Reader
+}
+
+// CreateWithAttachments creates an entry together with its attachments in
+// a single multipart request, so the entry is never visible without them.
+// The attachments map is keyed by attachment field name; the fields are set
+// to the file names in the entry values.
+//
+// Example usage:
+//
+//	entry, err := client.Entries().CreateWithAttachments(ctx, "HPD:Help Desk",
+//	    map[string]any{"Description": "Printer on fire"},
+//	    map[string]remedy.AttachmentUpload{
+//	        "z2AF Work Log01": {Filename: "printer.jpg", Data: file},
+//	    })
+//
+// The request body is streamed, so attachments are not buffered in memory.
+// WithMaxAttachmentSize applies to each attachment.
+func (s *entryService) CreateWithAttachments(ctx context.Context, form string, values map[string]any, attachments map[string]AttachmentUpload, opts ...WriteOption) (*Entry, error) {
+	if form == "" {
+		return nil, ErrEmptyFormName
+	}
+
+	selections, values, err := s.prepareMultipart(ctx, form, values, attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.client.acquireAndRateLimit(ctx); err != nil {
+		return nil, err
+	}
+	defer s.client.queue.Release()
+
+	path := buildWriteOptions(opts).path(entryPath(form))
+
+	resp, cancel, err := s.client.sendMultipart(ctx, http.MethodPost, path, values, attachments)
+	if err != nil {
+		return nil, fmt.Errorf("creating entry: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+		cancel()
+	}()
+
+	entry, err := s.client.decodeCreated(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	selections.apply(entry.Values, s.client.selectionFormat)
+
+	return entry, nil
+}
+
+// UpdateWithAttachments updates an entry and replaces its attachments in a
+// single multipart request. It accepts the same options as Update.
+func (s *entryService) UpdateWithAttachments(ctx context.Context, form, entryID string, values map[string]any, attachments map[string]AttachmentUpload, opts ...WriteOption) error {
+	if form == "" {
+		return ErrEmptyFormName
+	}
+	if entryID == "" {
+		return ErrEmptyEntryID
+	}
+
+	selections, values, err := s.prepareMultipart(ctx, form, values, attachments)
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.acquireAndRateLimit(ctx); err != nil {
+		return err
+	}
+	defer s.client.queue.Release()
+
+	o := buildWriteOptions(opts)
+
+	resp, cancel, err := s.client.sendMultipart(ctx, http.MethodPut, o.path(entryIDPath(form, entryID)), values, attachments)
+	if err != nil {
+		return fmt.Errorf("updating entry: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+		cancel()
+	}()
+
+	return s.updateResult(o, selections, func(target any) error {
+		return s.client.handleResponse(resp, target)
+	})
+}
+
+// prepareMultipart validates the attachments and converts the values for
+// a multipart write, setting each attachment field to its file name.
+func (s *entryService) prepareMultipart(ctx context.Context, form string, values map[string]any, attachments map[string]AttachmentUpload) (*SelectionMap, map[string]any, error) {
+	for field, attachment := range attachments {
+		if field == "" {
+			return nil, nil, ErrEmptyFieldName
+		}
+		if attachment.Filename == "" || attachment.Data == nil {
+			return nil, nil, fmt.Errorf("%w: missing file name or data for field %s", ErrInvalidAttachment, field)
+		}
+		if err := s.client.checkAttachmentSize(readerSize(attachment.Data)); err != nil {
+			return nil, nil, fmt.Errorf("field %s: %w", field, err)
+		}
+	}
+
+	selections, err := s.client.selectionsFor(ctx, form)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	converted, err := selections.toValues(values)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	merged := make(map[string]any, len(converted)+len(attachments))
+	maps.Copy(merged, converted)
+	for field, attachment := range attachments {
+		merged[field] = attachment.Filename
+	}
+
+	return selections, merged, nil
+}
+
+// sendMultipart sends entry values and attachments as a multipart request.
+// The body is written by a goroutine through a pipe. For successful
+// responses it waits until the body is written and returns any write error.
+// The caller must close the response body and call cancel.
+func (c *Client) sendMultipart(ctx context.Context, method, path string, values map[string]any, attachments map[string]AttachmentUpload) (*http.Response, context.CancelFunc, error) {
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	errCh := make(chan error, 1)
+	go func() {
+		err := c.writeMultipartEntry(writer, values, attachments)
+		if err == nil {
+			err = writer.Close()
+		}
+		// A write error aborts the request so no partial entry is stored
+		_ = pw.CloseWithError(err)
+		errCh <- err
+	}()
+
+	req, cancel, err := c.newRequest(ctx, method, path, pr)
+	if err != nil {
+		_ = pr.CloseWithError(err) // Unblock the writer goroutine
+		return nil, nil, err
+	}
+
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		cancel()
+		_ = pr.CloseWithError(err)
+		return nil, nil, wrapUploadError(ctx, err, errCh, nil)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		// The server may reject the request before reading the body
+		_ = pr.Close()
+		return resp, cancel, nil
+	}
+
+	if writeErr := <-errCh; writeErr != nil {
+		_ = resp.Body.Close()
+		cancel()
+		return nil, nil, writeErr
+	}
+
+	return resp, cancel, nil
+}
+
+// writeMultipartEntry writes the entry part as JSON, followed by one
+// "attach-<field>" part per attachment in field name order.
+func (c *Client) writeMultipartEntry(w *multipart.Writer, values map[string]any, attachments map[string]AttachmentUpload) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="entry"`)
+	header.Set("Content-Type", "application/json")
+
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("creating entry part: %w", err)
+	}
+	if err := json.NewEncoder(part).Encode(map[string]any{"values": values}); err != nil {
+		return fmt.Errorf("encoding entry: %w", err)
+	}
+
+	for _, field := range slices.Sorted(maps.Keys(attachments)) {
+		attachment := attachments[field]
+
+		part, err := w.CreateFormFile("attach-"+field, attachment.Filename)
+		if err != nil {
+			return fmt.Errorf("creating attachment part: %w", err)
+		}
+		if _, err := io.Copy(part, c.limitAttachment(attachment.Data, 0)); err != nil {
+			return fmt.Errorf("copying attachment %s: %w", field, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package remedy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// multipartPart is a decoded part of a multipart request.
+type multipartPart struct {
+	filename    string
+	contentType string
+	data        string
+}
+
+// readMultipart decodes a multipart request into its parts by form name.
+func readMultipart(t *testing.T, req *http.Request) map[string]multipartPart {
+	t.Helper()
+
+	mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/form-data", mediaType)
+
+	parts := make(map[string]multipartPart)
+	reader := multipart.NewReader(req.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(part)
+		require.NoError(t, err)
+		parts[part.FormName()] = multipartPart{
+			filename:    part.FileName(),
+			contentType: part.Header.Get("Content-Type"),
+			data:        string(data),
+		}
+	}
+
+	return parts
+}
+
+// entryPartValues decodes the values of the entry part.
+func entryPartValues(t *testing.T, parts map[string]multipartPart) map[string]any {
+	t.Helper()
+
+	entry, ok := parts["entry"]
+	require.True(t, ok, "missing entry part")
+	assert.Equal(t, "application/json", entry.contentType)
+
+	var body struct {
+		Values map[string]any `json:"values"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(entry.data), &body))
+
+	return body.Values
+}
+
+func TestEntryService_CreateWithAttachments(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "/api/arsys/v1/entry/HPD:Help Desk", req.URL.Path)
+
+		parts := readMultipart(t, req)
+		assert.Equal(t, map[string]any{
+			"Description":     "Printer on fire",
+			"z2AF Work Log01": "printer.jpg",
+			"z2AF Work Log02": "log.txt",
+		}, entryPartValues(t, parts))
+		assert.Equal(t, multipartPart{"printer.jpg", "application/octet-stream", "jpeg data"}, parts["attach-z2AF Work Log01"])
+		assert.Equal(t, "log.txt", parts["attach-z2AF Work Log02"].filename)
+		assert.Equal(t, "paper jam", parts["attach-z2AF Work Log02"].data)
+
+		resp := newMockResponse(http.StatusCreated, nil)
+		resp.Header.Set("Location", "https://remedy.example.com/api/arsys/v1/entry/HPD:Help Desk/000000000000001")
+		return resp, nil
+	})
+
+	entry, err := client.Entries().CreateWithAttachments(t.Context(), "HPD:Help Desk",
+		map[string]any{"Description": "Printer on fire"},
+		map[string]AttachmentUpload{
+			"z2AF Work Log01": {Filename: "printer.jpg", Data: strings.NewReader("jpeg data")},
+			"z2AF Work Log02": {Filename: "log.txt", Data: io.MultiReader(strings.NewReader("paper jam"))},
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, "000000000000001", entry.Values["Entry_id"])
+}
+
+func TestEntryService_UpdateWithAttachments(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "/api/arsys/v1/entry/Form/REQ1", req.URL.Path)
+		assert.Equal(t, "values(Status)", req.URL.Query().Get("fields"))
+
+		parts := readMultipart(t, req)
+		assert.Equal(t, map[string]any{"Status": "Resolved", "Attachment": "report.pdf"}, entryPartValues(t, parts))
+		assert.Equal(t, "pdf data", parts["attach-Attachment"].data)
+
+		return newMockResponse(http.StatusOK, Entry{Values: map[string]any{"Status": "Resolved"}}), nil
+	})
+
+	var result Entry
+	err := client.Entries().UpdateWithAttachments(t.Context(), "Form", "REQ1",
+		map[string]any{"Status": "Resolved"},
+		map[string]AttachmentUpload{"Attachment": {Filename: "report.pdf", Data: strings.NewReader("pdf data")}},
+		WithReturnFields("Status"), WithResult(&result))
+
+	require.NoError(t, err)
+	assert.Equal(t, "Resolved", result.Values["Status"])
+}
+
+func TestEntryService_UpdateWithAttachments_WithResultNoContent(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		_, err := io.Copy(io.Discard, req.Body)
+		require.NoError(t, err)
+
+		return newMockResponse(http.StatusNoContent, nil), nil
+	})
+
+	updated := Entry{Values: map[string]any{"stale": true}}
+	err := client.Entries().UpdateWithAttachments(t.Context(), "Form", "REQ1", nil,
+		map[string]AttachmentUpload{"Attachment": {Filename: "report.pdf", Data: strings.NewReader("pdf data")}},
+		WithResult(&updated))
+
+	require.NoError(t, err)
+	assert.Empty(t, updated.Values)
+}
+
+func TestEntryService_CreateWithAttachments_Rejected(t *testing.T) {
+	// The server rejects the request without reading the body
+	client := setupAuthenticatedClient(t, func(*http.Request) (*http.Response, error) {
+		body, _ := json.Marshal([]apiErrorResponse{{MessageType: "ERROR", MessageText: "Required field missing", MessageNumber: 326}})
+		return &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       io.NopCloser(bytes.NewReader(body)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	_, err := client.Entries().CreateWithAttachments(t.Context(), "Form", nil,
+		map[string]AttachmentUpload{"Attachment": {Filename: "a.bin", Data: bytes.NewReader(make([]byte, 1<<20))}})
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 326, apiErr.MessageNumber)
+}
+
+func TestEntryService_CreateWithAttachments_Invalid(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(*http.Request) (*http.Response, error) {
+		t.Error("request must not be sent")
+		return newMockResponse(http.StatusCreated, nil), nil
+	})
+	client.maxAttachmentSize = 10
+
+	tests := []struct {
+		name        string
+		attachments map[string]AttachmentUpload
+		wantErr     error
+	}{
+		{"empty field", map[string]AttachmentUpload{"": {Filename: "a.txt", Data: strings.NewReader("a")}}, ErrEmptyFieldName},
+		{"no file name", map[string]AttachmentUpload{"Attachment": {Data: strings.NewReader("a")}}, ErrInvalidAttachment},
+		{"no data", map[string]AttachmentUpload{"Attachment": {Filename: "a.txt"}}, ErrInvalidAttachment},
+		{"too large", map[string]AttachmentUpload{"Attachment": {Filename: "a.txt", Data: strings.NewReader(strings.Repeat("a", 11))}}, ErrAttachmentTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Entries().CreateWithAttachments(t.Context(), "Form", nil, tt.attachments)
+
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestEntryService_CreateWithAttachments_TooLargeWhileStreaming(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		if _, err := io.Copy(io.Discard, req.Body); err != nil {
+			return nil, err
+		}
+		return newMockResponse(http.StatusCreated, Entry{}), nil
+	})
+	client.maxAttachmentSize = 10
+
+	_, err := client.Entries().CreateWithAttachments(t.Context(), "Form", nil,
+		map[string]AttachmentUpload{"Attachment": {Filename: "a.txt", Data: io.MultiReader(strings.NewReader(strings.Repeat("a", 11)))}})
+
+	require.ErrorIs(t, err, ErrAttachmentTooLarge)
+}
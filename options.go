@@ -1,6 +1,7 @@
 package remedy
 
 import (
+	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
@@ -62,6 +63,25 @@ func WithAutoRefresh(enabled bool) Option {
 	}
 }
 
+// WithSelectionMapping enables conversion of selection field values using
+// form metadata. Create, Update and Merge accept either labels or stored
+// integer values and send the integer values; Get and List return selection
+// values in the given format. Metadata is fetched once per form and cached.
+func WithSelectionMapping(format SelectionFormat) Option {
+	return func(c *Client) {
+		c.selectionFormat = format
+	}
+}
+
+// WithMaxAttachmentSize limits the size of attachments downloaded with
+// Attachments().Get and uploaded with Attachments().Upload. Transfers of
+// larger files fail with ErrAttachmentTooLarge. The default is no limit.
+func WithMaxAttachmentSize(n int64) Option {
+	return func(c *Client) {
+		c.maxAttachmentSize = n
+	}
+}
+
 // QueryOption configures entry query operations.
 type QueryOption func(*queryOptions)
 
@@ -69,8 +89,7 @@ type QueryOption func(*queryOptions)
 type queryOptions struct {
 	fields        []string
 	qualification string
-	sortField     string
-	sortOrder     SortOrder
+	sort          []SortKey
 	limit         int
 	offset        int
 	expand        []string
@@ -93,8 +112,15 @@ func WithQualification(q string) QueryOption {
 // WithSort sets the field and order for sorting results.
 func WithSort(field string, order SortOrder) QueryOption {
 	return func(o *queryOptions) {
-		o.sortField = field
-		o.sortOrder = order
+		o.sort = []SortKey{{Field: field, Order: order}}
+	}
+}
+
+// WithSortBy sorts results by several fields, in order of precedence.
+// It replaces any sort set with WithSort.
+func WithSortBy(keys ...SortKey) QueryOption {
+	return func(o *queryOptions) {
+		o.sort = keys
 	}
 }
 
@@ -129,19 +155,15 @@ func buildQueryParams(opts []QueryOption) url.Values {
 	params := url.Values{}
 
 	if len(o.fields) > 0 {
-		params.Set("fields", "values("+strings.Join(o.fields, ",")+")")
+		params.Set("fields", fieldsParam(o.fields))
 	}
 
 	if o.qualification != "" {
 		params.Set("q", o.qualification)
 	}
 
-	if o.sortField != "" {
-		sortValue := o.sortField
-		if o.sortOrder == SortDesc {
-			sortValue += ".desc"
-		}
-		params.Set("sort", sortValue)
+	if sort := sortParam(o.sort); sort != "" {
+		params.Set("sort", sort)
 	}
 
 	if o.limit > 0 {
@@ -158,3 +180,199 @@ func buildQueryParams(opts []QueryOption) url.Values {
 
 	return params
 }
+
+// sortParam formats sort keys for the sort query parameter. Keys with an
+// empty field are skipped.
+func sortParam(keys []SortKey) string {
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key.Field == "" {
+			continue
+		}
+		value := key.Field
+		if key.Order == SortDesc {
+			value += ".desc"
+		}
+		values = append(values, value)
+	}
+
+	return strings.Join(values, ",")
+}
+
+// fieldsParam formats field names for the fields query parameter.
+func fieldsParam(fields []string) string {
+	return "values(" + strings.Join(fields, ",") + ")"
+}
+
+// WriteOption configures entry Create and Update operations.
+type WriteOption func(*writeOptions)
+
+// writeOptions holds the configuration for write operations.
+type writeOptions struct {
+	fields []string
+	result *Entry
+}
+
+// WithReturnFields requests the given fields back in the response to a
+// Create or Update, such as server-generated IDs or fields populated by
+// workflow, without a follow-up Get.
+func WithReturnFields(fields ...string) WriteOption {
+	return func(o *writeOptions) {
+		o.fields = fields
+	}
+}
+
+// WithResult stores the entry returned by an Update in dst.
+// Combine with WithReturnFields to choose which fields are returned.
+func WithResult(dst *Entry) WriteOption {
+	return func(o *writeOptions) {
+		o.result = dst
+	}
+}
+
+// buildWriteOptions applies write options.
+func buildWriteOptions(opts []WriteOption) *writeOptions {
+	o := &writeOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// path appends the requested return fields to an entry path.
+func (o *writeOptions) path(base string) string {
+	if len(o.fields) == 0 {
+		return base
+	}
+
+	params := url.Values{}
+	params.Set("fields", fieldsParam(o.fields))
+
+	return base + "?" + params.Encode()
+}
+
+// MergeOption configures entry Merge operations.
+type MergeOption func(*mergeOptions)
+
+// mergeOptions holds the configuration for merge operations.
+type mergeOptions struct {
+	mergeType      MergeType
+	ignoreRequired bool
+	ignorePatterns bool
+	noWorkflow     bool
+	qualification  string
+	multiMatch     MultiMatchOption
+}
+
+// mergeOptionsJSON is the wire format of the merge options.
+type mergeOptionsJSON struct {
+	MergeType        MergeType        `json:"mergeType,omitempty"`
+	IgnoreRequired   bool             `json:"ignoreRequired,omitempty"`
+	IgnorePatterns   bool             `json:"ignorePatterns,omitempty"`
+	WorkflowEnabled  *bool            `json:"workflowEnabled,omitempty"`
+	MultimatchOption MultiMatchOption `json:"multimatchOption,omitempty"`
+}
+
+// WithMergeType sets how the merge handles an existing entry with the same
+// Request ID. The server default is MergeErrorOnDuplicate.
+func WithMergeType(t MergeType) MergeOption {
+	return func(o *mergeOptions) {
+		o.mergeType = t
+	}
+}
+
+// WithIgnoreRequired skips required field checks during the merge.
+func WithIgnoreRequired() MergeOption {
+	return func(o *mergeOptions) {
+		o.ignoreRequired = true
+	}
+}
+
+// WithIgnorePatterns skips field pattern checks during the merge.
+func WithIgnorePatterns() MergeOption {
+	return func(o *mergeOptions) {
+		o.ignorePatterns = true
+	}
+}
+
+// WithoutWorkflow prevents filters from firing for the merge.
+func WithoutWorkflow() MergeOption {
+	return func(o *mergeOptions) {
+		o.noWorkflow = true
+	}
+}
+
+// WithMatchQualification matches existing entries by qualification instead
+// of Request ID. The multi option chooses what happens when several entries
+// match. Requires MergeOverwrite or MergeUpdateFields.
+func WithMatchQualification(qualification string, multi MultiMatchOption) MergeOption {
+	return func(o *mergeOptions) {
+		o.qualification = qualification
+		o.multiMatch = multi
+	}
+}
+
+// buildMergeOptions applies merge options.
+func buildMergeOptions(opts []MergeOption) *mergeOptions {
+	o := &mergeOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// validate checks that the merge options form a valid combination.
+func (o *mergeOptions) validate() error {
+	switch o.mergeType {
+	case "", MergeErrorOnDuplicate, MergeNewIDOnDuplicate, MergeOverwrite, MergeUpdateFields:
+	default:
+		return fmt.Errorf("%w: unknown merge type %q", ErrInvalidMergeOptions, o.mergeType)
+	}
+
+	switch o.multiMatch {
+	case MultiMatchError, MultiMatchFirst, MultiMatchAll:
+	default:
+		return fmt.Errorf("%w: unknown multi-match option %d", ErrInvalidMergeOptions, o.multiMatch)
+	}
+
+	if o.qualification == "" {
+		if o.multiMatch != MultiMatchError {
+			return fmt.Errorf("%w: multi-match option requires a match qualification", ErrInvalidMergeOptions)
+		}
+		return nil
+	}
+
+	if o.mergeType != MergeOverwrite && o.mergeType != MergeUpdateFields {
+		return fmt.Errorf("%w: match qualification requires merge type %s or %s",
+			ErrInvalidMergeOptions, MergeOverwrite, MergeUpdateFields)
+	}
+
+	return nil
+}
+
+// body builds the merge request body for values.
+func (o *mergeOptions) body(values map[string]any) map[string]any {
+	body := map[string]any{"values": values}
+
+	wire := mergeOptionsJSON{
+		MergeType:        o.mergeType,
+		IgnoreRequired:   o.ignoreRequired,
+		IgnorePatterns:   o.ignorePatterns,
+		MultimatchOption: o.multiMatch,
+	}
+	if o.noWorkflow {
+		enabled := false
+		wire.WorkflowEnabled = &enabled
+	}
+	if wire != (mergeOptionsJSON{}) {
+		body["mergeOptions"] = wire
+	}
+
+	if o.qualification != "" {
+		body["qualification"] = o.qualification
+	}
+
+	return body
+}
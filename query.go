@@ -18,6 +18,7 @@ import (
 type Query struct {
 	conditions []string
 	err        error // stores first validation error for BuildSafe
+	selections *SelectionMap
 }
 
 // validOperators contains the allowed operators for safe query building.
@@ -36,6 +37,19 @@ func NewQuery() *Query {
 	return &Query{}
 }
 
+// WithSelections converts selection labels in subsequent conditions to
+// their stored integer values, making the qualification independent of
+// the server locale. Labels that do not match any option are kept as-is
+// and reported by BuildSafe.
+//
+//	m, err := client.Forms().Selections(ctx, "HPD:Help Desk")
+//	q := remedy.NewQuery().WithSelections(m).And("Status", "=", "Assigned").Build()
+//	// Result: 'Status' = 1
+func (q *Query) WithSelections(m *SelectionMap) *Query {
+	q.selections = m
+	return q
+}
+
 // And adds a condition with AND conjunction.
 func (q *Query) And(field, op string, value any) *Query {
 	q.addCondition("AND", field, op, value)
@@ -107,13 +121,35 @@ func (q *Query) addCondition(conjunction, field, op string, value any) {
 		q.conditions = append(q.conditions, conjunction)
 	}
 
-	condition := formatCondition(field, op, value)
+	condition := formatCondition(field, op, q.selectionValue(field, op, value))
 	q.conditions = append(q.conditions, condition)
 }
 
+// selectionValue converts a selection label to its stored value when a
+// selection map is set. LIKE patterns are not labels and are left unchanged.
+func (q *Query) selectionValue(field, op string, value any) any {
+	if _, isLabel := value.(string); !isLabel || q.selections == nil || op == OpLike {
+		return value
+	}
+
+	converted, err := q.selections.Value(field, value)
+	if err != nil {
+		if q.err == nil {
+			q.err = err
+		}
+		return value
+	}
+
+	return converted
+}
+
 // formatCondition formats a single field condition.
 // Field names have single quotes escaped by doubling them to prevent injection.
 func formatCondition(field, op string, value any) string {
+	if condition, ok := formatCurrencyCondition(field, op, value); ok {
+		return condition
+	}
+
 	formattedValue := formatValue(value)
 	escapedField := escapeFieldName(field)
 	return fmt.Sprintf("'%s' %s %s", escapedField, op, formattedValue)
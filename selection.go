@@ -0,0 +1,218 @@
+package remedy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownSelection indicates a label does not match any option of a selection field.
+var ErrUnknownSelection = errors.New("remedy: unknown selection label")
+
+// SelectionFormat controls how selection field values are represented.
+type SelectionFormat int
+
+const (
+	// SelectionAsIs leaves selection values exactly as sent and returned
+	// by the server. This is the default.
+	SelectionAsIs SelectionFormat = iota
+
+	// SelectionLabels represents selection values by their display labels.
+	SelectionLabels
+
+	// SelectionValues represents selection values by their stored integers,
+	// which do not depend on the server locale.
+	SelectionValues
+)
+
+// SelectionMap maps selection field values between display labels and
+// stored integer values for a single form. It is built from form metadata
+// and is safe for concurrent use.
+type SelectionMap struct {
+	fields map[string]selectionField
+}
+
+// selectionField holds both directions of the mapping for one field.
+type selectionField struct {
+	byLabel map[string]int
+	byValue map[int]string
+}
+
+// noSelections is used when selection mapping is disabled.
+var noSelections = &SelectionMap{}
+
+// NewSelectionMap builds a selection map from form field metadata.
+// Fields that are not selection fields are ignored.
+func NewSelectionMap(fields []Field) *SelectionMap {
+	m := &SelectionMap{fields: make(map[string]selectionField)}
+
+	for _, f := range fields {
+		if f.DataType != DataTypeSelection {
+			continue
+		}
+
+		sf := selectionField{
+			byLabel: make(map[string]int, len(f.SelectionValues)),
+			byValue: make(map[int]string, len(f.SelectionValues)),
+		}
+		for _, sv := range f.SelectionValues {
+			sf.byLabel[sv.Label] = sv.Value
+			sf.byValue[sv.Value] = sv.Label
+		}
+		m.fields[f.Name] = sf
+	}
+
+	return m
+}
+
+// IsSelection reports whether the named field is a selection field.
+func (m *SelectionMap) IsSelection(field string) bool {
+	_, ok := m.fields[field]
+	return ok
+}
+
+// Value converts a selection label or number to the stored integer value.
+// Values of fields that are not selection fields are returned unchanged.
+func (m *SelectionMap) Value(field string, v any) (any, error) {
+	sf, ok := m.fields[field]
+	if !ok || v == nil {
+		return v, nil
+	}
+
+	if label, ok := v.(string); ok {
+		if n, ok := sf.lookupLabel(label); ok {
+			return n, nil
+		}
+		if n, err := strconv.Atoi(label); err == nil {
+			return n, nil
+		}
+		return nil, fmt.Errorf("%w: %q for field %q", ErrUnknownSelection, label, field)
+	}
+
+	if n, ok := selectionNumber(v); ok {
+		return n, nil
+	}
+
+	return v, nil
+}
+
+// Label converts a selection number or label to the display label.
+// Numbers without a matching option and values of fields that are not
+// selection fields are returned unchanged.
+func (m *SelectionMap) Label(field string, v any) any {
+	sf, ok := m.fields[field]
+	if !ok {
+		return v
+	}
+
+	if label, ok := v.(string); ok {
+		if n, ok := sf.lookupLabel(label); ok {
+			return sf.byValue[n]
+		}
+		return v
+	}
+
+	if n, ok := selectionNumber(v); ok {
+		if label, ok := sf.byValue[n]; ok {
+			return label
+		}
+	}
+
+	return v
+}
+
+// lookupLabel finds the stored value for a label, falling back to a
+// case-insensitive match.
+func (sf selectionField) lookupLabel(label string) (int, bool) {
+	if n, ok := sf.byLabel[label]; ok {
+		return n, true
+	}
+
+	for l, n := range sf.byLabel {
+		if strings.EqualFold(l, label) {
+			return n, true
+		}
+	}
+
+	return 0, false
+}
+
+// selectionNumber extracts an integer from numeric value types, including
+// the float64 produced by JSON decoding.
+func selectionNumber(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int32:
+		return int(n), true
+	case int64:
+		return int(n), true
+	case float64:
+		if n != math.Trunc(n) {
+			return 0, false
+		}
+		return int(n), true
+	default:
+		return 0, false
+	}
+}
+
+// toValues returns a copy of values with selection fields converted to
+// their stored integer values.
+func (m *SelectionMap) toValues(values map[string]any) (map[string]any, error) {
+	if len(m.fields) == 0 {
+		return values, nil
+	}
+
+	converted := maps.Clone(values)
+	for field, v := range values {
+		n, err := m.Value(field, v)
+		if err != nil {
+			return nil, err
+		}
+		converted[field] = n
+	}
+
+	return converted, nil
+}
+
+// apply converts the selection fields of values in place to the given format.
+func (m *SelectionMap) apply(values map[string]any, format SelectionFormat) {
+	if len(m.fields) == 0 {
+		return
+	}
+
+	for field, v := range values {
+		switch format {
+		case SelectionLabels:
+			values[field] = m.Label(field, v)
+		case SelectionValues:
+			if n, err := m.Value(field, v); err == nil {
+				values[field] = n
+			}
+		case SelectionAsIs:
+			return
+		}
+	}
+}
+
+// selectionsFor returns the selection map used to convert entries of form.
+// When selection mapping is disabled it returns an empty map, so callers
+// can convert unconditionally. It must be called before acquiring the
+// request queue because loading metadata performs a request.
+func (c *Client) selectionsFor(ctx context.Context, form string) (*SelectionMap, error) {
+	if c.selectionFormat == SelectionAsIs {
+		return noSelections, nil
+	}
+
+	m, err := c.forms.Selections(ctx, form)
+	if err != nil {
+		return nil, fmt.Errorf("loading selection metadata: %w", err)
+	}
+
+	return m, nil
+}
@@ -0,0 +1,127 @@
+package remedy
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectionMap_Value(t *testing.T) {
+	m := NewSelectionMap([]Field{testStatusField, {Name: "Summary", DataType: DataTypeCharacter}})
+
+	tests := []struct {
+		name     string
+		field    string
+		value    any
+		expected any
+	}{
+		{name: "label", field: "Status", value: "Assigned", expected: 1},
+		{name: "label case-insensitive", field: "Status", value: "closed", expected: 4},
+		{name: "integer", field: "Status", value: 4, expected: 4},
+		{name: "decoded JSON number", field: "Status", value: float64(1), expected: 1},
+		{name: "numeric string", field: "Status", value: "0", expected: 0},
+		{name: "null", field: "Status", value: nil, expected: nil},
+		{name: "non-selection field", field: "Summary", value: "Assigned", expected: "Assigned"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.Value(tt.field, tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestSelectionMap_Value_UnknownLabel(t *testing.T) {
+	m := NewSelectionMap([]Field{testStatusField})
+
+	_, err := m.Value("Status", "Pending")
+
+	assert.ErrorIs(t, err, ErrUnknownSelection)
+}
+
+func TestSelectionMap_Label(t *testing.T) {
+	m := NewSelectionMap([]Field{testStatusField})
+
+	assert.Equal(t, "Assigned", m.Label("Status", float64(1)))
+	assert.Equal(t, "Closed", m.Label("Status", "closed"))
+	assert.Equal(t, float64(9), m.Label("Status", float64(9)), "unknown values are kept")
+	assert.Equal(t, "x", m.Label("Summary", "x"))
+}
+
+func TestQuery_WithSelections(t *testing.T) {
+	m := NewSelectionMap([]Field{testStatusField})
+
+	q, err := NewQuery().
+		WithSelections(m).
+		AndSafe("Status", "=", "Assigned").
+		AndSafe("Summary", OpLike, "%printer%").
+		BuildSafe()
+
+	require.NoError(t, err)
+	assert.Equal(t, `'Status' = 1 AND 'Summary' LIKE "%printer%"`, q)
+}
+
+func TestQuery_WithSelections_UnknownLabel(t *testing.T) {
+	m := NewSelectionMap([]Field{testStatusField})
+
+	_, err := NewQuery().WithSelections(m).And("Status", "=", "Pending").BuildSafe()
+
+	assert.ErrorIs(t, err, ErrUnknownSelection)
+}
+
+// newSelectionMappingClient returns an authenticated client with selection
+// mapping enabled that serves testStatusField as form metadata.
+func newSelectionMappingClient(t *testing.T, format SelectionFormat, doFunc func(*http.Request) (*http.Response, error)) *Client {
+	t.Helper()
+
+	client := setupFormClient(t, []Field{testStatusField}, doFunc)
+	client.selectionFormat = format
+
+	return client
+}
+
+func TestEntryService_Create_MapsSelectionLabels(t *testing.T) {
+	client := newSelectionMappingClient(t, SelectionLabels, func(req *http.Request) (*http.Response, error) {
+		var body map[string]map[string]any
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.InDelta(t, 1, body["values"]["Status"], 0)
+
+		return newMockResponse(http.StatusCreated, Entry{Values: map[string]any{"Status": 1}}), nil
+	})
+
+	entry, err := client.Entries().Create(t.Context(), "Form", map[string]any{"Status": "Assigned"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Assigned", entry.Values["Status"])
+}
+
+func TestEntryService_Update_UnknownSelectionLabel(t *testing.T) {
+	client := newSelectionMappingClient(t, SelectionValues, func(_ *http.Request) (*http.Response, error) {
+		t.Fatal("update must not be sent with an unknown label")
+		return nil, nil
+	})
+
+	err := client.Entries().Update(t.Context(), "Form", "ID", map[string]any{"Status": "Pending"})
+
+	assert.ErrorIs(t, err, ErrUnknownSelection)
+}
+
+func TestEntryService_List_ReturnsSelectionValues(t *testing.T) {
+	client := newSelectionMappingClient(t, SelectionValues, func(_ *http.Request) (*http.Response, error) {
+		return newMockResponse(http.StatusOK, EntryList{Entries: []Entry{
+			{Values: map[string]any{"Status": "Assigned"}},
+			{Values: map[string]any{"Status": "Closed"}},
+		}}), nil
+	})
+
+	list, err := client.Entries().List(t.Context(), "Form")
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.Entries[0].Values["Status"])
+	assert.Equal(t, 4, list.Entries[1].Values["Status"])
+}
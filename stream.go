@@ -0,0 +1,159 @@
+package remedy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+)
+
+// errStopStream signals that the consumer stopped iterating.
+var errStopStream = errors.New("stream stopped")
+
+// Stream lists entries like List but decodes the response incrementally,
+// yielding each entry as it is read from the response body instead of
+// holding the whole page in memory.
+//
+// Example usage:
+//
+//	for entry, err := range client.Entries().Stream(ctx, "HPD:Help Desk", remedy.WithLimit(5000)) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    process(entry)
+//	}
+//
+// The request holds the client's request queue until iteration finishes,
+// so other calls on the same client block until then; do not call the
+// client from inside the loop. Breaking out of the loop closes the response
+// body and releases the queue. The client timeout covers the whole stream.
+//
+// With WithSelectionMapping, the form's selection values are converted as
+// in List. Entries embedded by WithExpand are only converted if the related
+// form's selection map is already loaded, because loading metadata needs
+// the queue the stream is holding; call Forms().Selections for the related
+// forms before streaming to have them converted.
+func (s *entryService) Stream(ctx context.Context, form string, opts ...QueryOption) iter.Seq2[*Entry, error] {
+	return func(yield func(*Entry, error) bool) {
+		if form == "" {
+			yield(nil, ErrEmptyFormName)
+			return
+		}
+
+		err := s.stream(ctx, form, opts, yield)
+		if err != nil && !errors.Is(err, errStopStream) {
+			yield(nil, err)
+		}
+	}
+}
+
+// stream performs the request and yields the decoded entries.
+func (s *entryService) stream(ctx context.Context, form string, opts []QueryOption, yield func(*Entry, error) bool) error {
+	selections, err := s.client.selectionsFor(ctx, form)
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.acquireAndRateLimit(ctx); err != nil {
+		return err
+	}
+	defer s.client.queue.Release()
+
+	path := entryPath(form)
+	if params := buildQueryParams(opts); len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	req, cancel, err := s.client.newJSONRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return fmt.Errorf("creating list request: %w", err)
+	}
+	defer cancel()
+
+	resp, err := s.client.do(req)
+	if err != nil {
+		return fmt.Errorf("listing entries: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("listing entries: %w", s.client.parseAPIError(resp))
+	}
+
+	return decodeEntryStream(resp.Body, func(e *Entry) bool {
+		selections.apply(e.Values, s.client.selectionFormat)
+		s.mapEmbeddedCached(e)
+		return yield(e, nil)
+	})
+}
+
+// decodeEntryStream reads an EntryList object from r and calls fn with each
+// element of its "entries" array as it is decoded. Other members are skipped.
+// It returns errStopStream if fn returns false.
+func decodeEntryStream(r io.Reader, fn func(*Entry) bool) error {
+	dec := json.NewDecoder(r)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("decoding response: %w", err)
+		}
+
+		if tok != "entries" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return fmt.Errorf("decoding response: %w", err)
+			}
+			continue
+		}
+
+		if err := decodeEntryArray(dec, fn); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+// decodeEntryArray decodes the elements of a JSON array of entries.
+func decodeEntryArray(dec *json.Decoder, fn func(*Entry) bool) error {
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		var entry Entry
+		if err := dec.Decode(&entry); err != nil {
+			return fmt.Errorf("decoding entry: %w", err)
+		}
+
+		if !fn(&entry) {
+			return errStopStream
+		}
+	}
+
+	return expectDelim(dec, ']')
+}
+
+// expectDelim reads the next token and checks that it is the delimiter d.
+func expectDelim(dec *json.Decoder, d json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+
+	if tok != d {
+		return fmt.Errorf("decoding response: expected %q, got %v", d, tok)
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package remedy
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// trackingBody records whether the response body was closed.
+type trackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+// rawResponse returns a response with the given JSON text as body.
+func rawResponse(statusCode int, body string) (*http.Response, *trackingBody) {
+	tb := &trackingBody{Reader: strings.NewReader(body)}
+	return &http.Response{StatusCode: statusCode, Body: tb, Header: make(http.Header)}, tb
+}
+
+func TestEntryService_Stream(t *testing.T) {
+	body := `{
+		"_links": {"self": [{"href": "https://remedy.example.com/api/arsys/v1/entry/Form"}]},
+		"entries": [
+			{"values": {"Request ID": "REQ1", "Notes": {"long": "text"}}},
+			{"values": {"Request ID": "REQ2"}}
+		],
+		"totalSize": 2
+	}`
+	var tb *trackingBody
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "5000", req.URL.Query().Get("limit"))
+		var resp *http.Response
+		resp, tb = rawResponse(http.StatusOK, body)
+		return resp, nil
+	})
+
+	var ids []string
+	for entry, err := range client.Entries().Stream(t.Context(), "Form", WithLimit(5000)) {
+		require.NoError(t, err)
+		ids = append(ids, entry.Values["Request ID"].(string))
+	}
+
+	assert.Equal(t, []string{"REQ1", "REQ2"}, ids)
+	assert.True(t, tb.closed)
+}
+
+func TestEntryService_Stream_Break(t *testing.T) {
+	var tb *trackingBody
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/REQ9") {
+			return newMockResponse(http.StatusOK, Entry{Values: map[string]any{"Request ID": "REQ9"}}), nil
+		}
+		var resp *http.Response
+		resp, tb = rawResponse(http.StatusOK, `{"entries": [{"values": {"Request ID": "REQ1"}}, {"values": `)
+		return resp, nil
+	})
+
+	for entry, err := range client.Entries().Stream(t.Context(), "Form") {
+		require.NoError(t, err)
+		assert.Equal(t, "REQ1", entry.Values["Request ID"])
+		break
+	}
+
+	assert.True(t, tb.closed)
+
+	// The queue was released, so the client accepts further requests.
+	entry, err := client.Entries().Get(t.Context(), "Form", "REQ9")
+	require.NoError(t, err)
+	assert.Equal(t, "REQ9", entry.Values["Request ID"])
+}
+
+func TestEntryService_Stream_Truncated(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(_ *http.Request) (*http.Response, error) {
+		resp, _ := rawResponse(http.StatusOK, `{"entries": [{"values": {"Request ID": "REQ1"}}, {"values": {"Req`)
+		return resp, nil
+	})
+
+	var ids []string
+	var errs []error
+	for entry, err := range client.Entries().Stream(t.Context(), "Form") {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, entry.Values["Request ID"].(string))
+	}
+
+	assert.Equal(t, []string{"REQ1"}, ids)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "decoding entry")
+}
+
+func TestEntryService_Stream_APIError(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(_ *http.Request) (*http.Response, error) {
+		return newMockResponse(http.StatusBadRequest, []apiErrorResponse{
+			{MessageType: "ERROR", MessageText: "Invalid qualification", MessageNumber: 1587},
+		}), nil
+	})
+
+	var errs []error
+	for _, err := range client.Entries().Stream(t.Context(), "Form", WithQualification("bad")) {
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 1)
+	var apiErr *APIError
+	require.ErrorAs(t, errs[0], &apiErr)
+	assert.Equal(t, 1587, apiErr.MessageNumber)
+}
+
+func TestEntryService_Stream_EmptyFormName(t *testing.T) {
+	client := New("https://remedy.example.com")
+
+	var errs []error
+	for _, err := range client.Entries().Stream(t.Context(), "") {
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrEmptyFormName)
+}
+
+func TestEntryService_Stream_MapsEmbeddedSelectionsOfLoadedForms(t *testing.T) {
+	body := `{"entries": [{
+		"values": {"Status": 0},
+		"_embedded": {
+			"HPD:INC:Work Info": [{
+				"values": {"Status": 4},
+				"_links": [{"rel": "self", "href": "https://remedy.example.com/api/arsys/v1/entry/HPD:Work%20Log/WLG1"}]
+			}],
+			"HPD:INC:Task": [{
+				"values": {"Status": 4},
+				"_links": [{"rel": "self", "href": "https://remedy.example.com/api/arsys/v1/entry/TMS:Task/TAS1"}]
+			}]
+		}
+	}]}`
+
+	client := newSelectionMappingClient(t, SelectionLabels, func(*http.Request) (*http.Response, error) {
+		resp, _ := rawResponse(http.StatusOK, body)
+		return resp, nil
+	})
+
+	_, err := client.Forms().Selections(t.Context(), "HPD:Work Log")
+	require.NoError(t, err)
+
+	var entries []*Entry
+	for entry, err := range client.Entries().Stream(t.Context(), "HPD:Help Desk", WithExpand("HPD:INC:Work Info", "HPD:INC:Task")) {
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+
+	require.Len(t, entries, 1)
+	assert.Equal(t, "New", entries[0].Values["Status"])
+	assert.Equal(t, "Closed", entries[0].Embedded["HPD:INC:Work Info"][0].Values["Status"])
+	assert.InDelta(t, 4, entries[0].Embedded["HPD:INC:Task"][0].Values["Status"], 0)
+}
@@ -4,12 +4,20 @@ package remedy
 type Entry struct {
 	Values map[string]any `json:"values"`
 	Links  []Link         `json:"_links,omitzero"`
+
+	// Embedded holds associated entries returned when associations are
+	// expanded with WithExpand, keyed by association name.
+	Embedded map[string][]Entry `json:"_embedded,omitzero"`
 }
 
 // EntryList represents a collection of entries returned from a list query.
 type EntryList struct {
 	Entries []Entry `json:"entries"`
 	Links   []Link  `json:"_links,omitzero"`
+
+	// TotalSize is the total number of entries matching the query across
+	// all pages. It is nil when the server does not report it.
+	TotalSize *int `json:"totalSize,omitzero"`
 }
 
 // Link represents a HATEOAS link in API responses.
@@ -23,8 +31,33 @@ type Field struct {
 	ID       int    `json:"fieldId"`
 	Name     string `json:"fieldName"`
 	DataType string `json:"dataType"`
+
+	// SelectionValues lists the options of a selection field.
+	SelectionValues []SelectionValue `json:"selectionValues,omitzero"`
+}
+
+// SelectionValue is one option of a selection field: the integer value
+// stored in the database and its (possibly localized) display label.
+type SelectionValue struct {
+	Value int    `json:"id"`
+	Label string `json:"name"`
 }
 
+// Field data types reported in form metadata.
+const (
+	DataTypeCharacter  = "CHARACTER"
+	DataTypeInteger    = "INTEGER"
+	DataTypeReal       = "REAL"
+	DataTypeDecimal    = "DECIMAL"
+	DataTypeSelection  = "SELECTION"
+	DataTypeDateTime   = "DATE_TIME"
+	DataTypeDate       = "DATE"
+	DataTypeTime       = "TIME_OF_DAY"
+	DataTypeDiary      = "DIARY"
+	DataTypeCurrency   = "CURRENCY"
+	DataTypeAttachment = "ATTACHMENT"
+)
+
 // SortOrder defines the sort direction for queries.
 type SortOrder string
 
@@ -35,6 +68,12 @@ const (
 	SortDesc SortOrder = "DESC"
 )
 
+// SortKey is a field and direction to sort query results by.
+type SortKey struct {
+	Field string
+	Order SortOrder
+}
+
 // DeleteOption defines options for delete operations.
 type DeleteOption string
 
@@ -47,6 +86,33 @@ const (
 	DeleteOptionNoCascade DeleteOption = "NOCASCADE"
 )
 
+// MergeType defines how a merge handles an entry whose Request ID already exists.
+type MergeType string
+
+const (
+	// MergeErrorOnDuplicate fails the merge if the entry already exists.
+	MergeErrorOnDuplicate MergeType = "DUP_ERROR"
+	// MergeNewIDOnDuplicate creates a new entry with a new Request ID.
+	MergeNewIDOnDuplicate MergeType = "DUP_NEW_ID"
+	// MergeOverwrite replaces the existing entry; fields not supplied are cleared.
+	MergeOverwrite MergeType = "DUP_OVERWRITE"
+	// MergeUpdateFields updates only the supplied fields of the existing entry.
+	MergeUpdateFields MergeType = "DUP_MERGE"
+)
+
+// MultiMatchOption defines what a merge does when its match qualification
+// finds more than one entry.
+type MultiMatchOption int
+
+const (
+	// MultiMatchError fails the merge when more than one entry matches.
+	MultiMatchError MultiMatchOption = iota
+	// MultiMatchFirst merges into the first matching entry.
+	MultiMatchFirst
+	// MultiMatchAll merges into every matching entry.
+	MultiMatchAll
+)
+
 // apiErrorResponse represents the error format returned by BMC Remedy REST API.
 type apiErrorResponse struct {
 	MessageType         string `json:"messageType"`
@@ -0,0 +1,159 @@
+package remedy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Kinds of upload failures, matched by UploadError with errors.Is.
+var (
+	// ErrUploadSource indicates that reading the data to upload failed.
+	ErrUploadSource = errors.New("remedy: reading upload data failed")
+
+	// ErrUploadRejected indicates that the server rejected the upload.
+	ErrUploadRejected = errors.New("remedy: upload rejected by server")
+
+	// ErrUploadCanceled indicates that the context was canceled or its
+	// deadline exceeded during the upload.
+	ErrUploadCanceled = errors.New("remedy: upload canceled")
+)
+
+// UploadError describes a failed upload. Kind is ErrUploadSource,
+// ErrUploadRejected or ErrUploadCanceled, and errors.Is matches both Kind
+// and the underlying error, such as an *APIError for rejected uploads or
+// context.Canceled for canceled ones.
+type UploadError struct {
+	Kind error
+	Err  error
+
+	// Sent is the number of bytes read from the data before the failure.
+	Sent int64
+}
+
+// Error implements the error interface.
+func (e *UploadError) Error() string {
+	return fmt.Sprintf("%v after %d bytes: %v", e.Kind, e.Sent, e.Err)
+}
+
+// Unwrap returns the kind and the underlying error.
+func (e *UploadError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// UploadStats describes a completed or failed upload.
+type UploadStats struct {
+	// Bytes is the number of bytes read from the data.
+	Bytes int64
+
+	// Duration is the time from the start of the request until it completed.
+	Duration time.Duration
+}
+
+// Throughput returns the upload rate in bytes per second.
+func (s UploadStats) Throughput() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+
+	return float64(s.Bytes) / s.Duration.Seconds()
+}
+
+// UploadOption configures Upload.
+type UploadOption func(*uploadOptions)
+
+// uploadOptions holds the configuration for Upload.
+type uploadOptions struct {
+	progress func(sent, total int64)
+	stats    *UploadStats
+}
+
+// WithUploadProgress calls fn as data is sent with the number of bytes sent
+// so far and the total size, or -1 if the size of the reader is unknown.
+// The size is known for readers with a Len method, such as bytes.Reader,
+// and for seekable readers, such as os.File.
+func WithUploadProgress(fn func(sent, total int64)) UploadOption {
+	return func(o *uploadOptions) {
+		o.progress = fn
+	}
+}
+
+// WithUploadStats stores the bytes sent and the duration of the upload in
+// dst when Upload returns, including when it fails.
+func WithUploadStats(dst *UploadStats) UploadOption {
+	return func(o *uploadOptions) {
+		o.stats = dst
+	}
+}
+
+// uploadSource wraps the caller's reader to count bytes, report progress
+// and record read failures. It is read by the multipart goroutine.
+type uploadSource struct {
+	r        io.Reader
+	total    int64
+	progress func(sent, total int64)
+
+	sent atomic.Int64
+
+	mu  sync.Mutex
+	err error
+}
+
+func (s *uploadSource) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	sent := s.sent.Add(int64(n))
+
+	if n > 0 && s.progress != nil {
+		s.progress(sent, s.total)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.mu.Lock()
+		s.err = err
+		s.mu.Unlock()
+	}
+
+	return n, err
+}
+
+// failure returns the read error, if any.
+func (s *uploadSource) failure() error {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+// bytesSent returns the number of bytes read so far.
+func (s *uploadSource) bytesSent() int64 {
+	if s == nil {
+		return 0
+	}
+
+	return s.sent.Load()
+}
+
+// classifyUpload returns an UploadError for reader failures and canceled
+// contexts, including the request deadline set by WithTimeout. Other errors
+// are wrapped with any write error of the multipart goroutine.
+func classifyUpload(ctx context.Context, err, writeErr error, source *uploadSource) error {
+	switch {
+	case source.failure() != nil:
+		return &UploadError{Kind: ErrUploadSource, Err: source.failure(), Sent: source.bytesSent()}
+	case ctx.Err() != nil:
+		return &UploadError{Kind: ErrUploadCanceled, Err: ctx.Err(), Sent: source.bytesSent()}
+	case errors.Is(err, context.DeadlineExceeded):
+		return &UploadError{Kind: ErrUploadCanceled, Err: err, Sent: source.bytesSent()}
+	case writeErr != nil && !errors.Is(err, writeErr):
+		return fmt.Errorf("uploading attachment: %w (write error: %w)", err, writeErr)
+	default:
+		return fmt.Errorf("uploading attachment: %w", err)
+	}
+}
@@ -0,0 +1,153 @@
+package remedy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drainingClient reads upload bodies and responds with status.
+func drainingClient(t *testing.T, status int) *Client {
+	t.Helper()
+
+	return setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		if _, err := io.Copy(io.Discard, req.Body); err != nil {
+			return nil, err
+		}
+		return newMockResponse(status, nil), nil
+	})
+}
+
+func TestAttachmentService_Upload_Progress(t *testing.T) {
+	client := drainingClient(t, http.StatusNoContent)
+	data := strings.Repeat("x", 100000)
+
+	var progress [][2]int64
+	var stats UploadStats
+	err := client.Attachments().Upload(t.Context(), "Form", "EntryID", "AttachField", "a.txt",
+		strings.NewReader(data),
+		WithUploadProgress(func(sent, total int64) {
+			progress = append(progress, [2]int64{sent, total})
+		}),
+		WithUploadStats(&stats))
+
+	require.NoError(t, err)
+	require.NotEmpty(t, progress)
+	assert.Equal(t, [2]int64{100000, 100000}, progress[len(progress)-1])
+	assert.Equal(t, int64(100000), stats.Bytes)
+	assert.Positive(t, stats.Duration)
+	assert.Positive(t, stats.Throughput())
+}
+
+func TestAttachmentService_Upload_ProgressUnknownTotal(t *testing.T) {
+	client := drainingClient(t, http.StatusNoContent)
+
+	var total int64
+	err := client.Attachments().Upload(t.Context(), "Form", "EntryID", "AttachField", "a.txt",
+		io.MultiReader(strings.NewReader("data")),
+		WithUploadProgress(func(_, t int64) { total = t }))
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1), total)
+}
+
+func TestAttachmentService_Upload_SourceFailure(t *testing.T) {
+	client := drainingClient(t, http.StatusNoContent)
+	errDisk := errors.New("disk read error")
+
+	var stats UploadStats
+	err := client.Attachments().Upload(t.Context(), "Form", "EntryID", "AttachField", "a.txt",
+		io.MultiReader(strings.NewReader("12345"), iotest.ErrReader(errDisk)),
+		WithUploadStats(&stats))
+
+	require.ErrorIs(t, err, ErrUploadSource)
+	require.ErrorIs(t, err, errDisk)
+	assert.NotErrorIs(t, err, ErrUploadRejected)
+
+	var uploadErr *UploadError
+	require.ErrorAs(t, err, &uploadErr)
+	assert.Equal(t, int64(5), uploadErr.Sent)
+	assert.Equal(t, int64(5), stats.Bytes)
+}
+
+func TestAttachmentService_Upload_Rejected(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(*http.Request) (*http.Response, error) {
+		// rejects without reading the body
+		return newMockResponse(http.StatusForbidden, []apiErrorResponse{{MessageType: "ERROR", MessageText: "Access denied"}}), nil
+	})
+
+	err := client.Attachments().Upload(t.Context(), "Form", "EntryID", "AttachField", "a.txt",
+		strings.NewReader(strings.Repeat("x", 1<<20)))
+
+	require.ErrorIs(t, err, ErrUploadRejected)
+	require.ErrorIs(t, err, ErrForbidden)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "Access denied", apiErr.MessageText)
+}
+
+func TestAttachmentService_Upload_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		cancel()
+		return nil, req.Context().Err()
+	})
+
+	err := client.Attachments().Upload(ctx, "Form", "EntryID", "AttachField", "a.txt",
+		strings.NewReader("data"))
+
+	require.ErrorIs(t, err, ErrUploadCanceled)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, ErrUploadSource)
+}
+
+func TestAttachmentService_Upload_CanceledInQueue(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request %s", req.URL)
+		return newMockResponse(http.StatusNoContent, nil), nil
+	})
+
+	// hold the request queue so Upload waits for it
+	require.NoError(t, client.queue.Acquire(t.Context()))
+	defer client.queue.Release()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	var stats UploadStats
+	err := client.Attachments().Upload(ctx, "Form", "EntryID", "AttachField", "a.txt",
+		strings.NewReader("data"), WithUploadStats(&stats))
+
+	require.ErrorIs(t, err, ErrUploadCanceled)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	var uploadErr *UploadError
+	require.ErrorAs(t, err, &uploadErr)
+	assert.Zero(t, uploadErr.Sent)
+	assert.Positive(t, stats.Duration)
+}
+
+func TestAttachmentService_Upload_ClientTimeout(t *testing.T) {
+	client := setupAuthenticatedClient(t, func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+	client.timeout = 10 * time.Millisecond
+
+	err := client.Attachments().Upload(t.Context(), "Form", "EntryID", "AttachField", "a.txt",
+		strings.NewReader("data"))
+
+	require.ErrorIs(t, err, ErrUploadCanceled)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
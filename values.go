@@ -0,0 +1,103 @@
+package remedy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// arTimeLayouts lists the timestamp formats returned by the AR REST API.
+// Servers emit ISO 8601 with a numeric offset lacking the colon
+// (e.g. 2024-01-15T10:30:00.000+0000); RFC 3339 is accepted as well.
+var arTimeLayouts = []string{
+	"2006-01-02T15:04:05.000-0700",
+	"2006-01-02T15:04:05-0700",
+	time.RFC3339Nano,
+}
+
+// parseTimeValue converts a timestamp value from Entry.Values to time.Time.
+// Strings are parsed using the AR REST formats; numbers are treated as
+// Unix epoch seconds, which is how AR stores date/time fields internally.
+func parseTimeValue(v any) (time.Time, error) {
+	switch val := v.(type) {
+	case time.Time:
+		return val, nil
+	case string:
+		if t, ok := parseTimeString(val); ok {
+			return t, nil
+		}
+		if secs, err := strconv.ParseInt(val, 10, 64); err == nil {
+			return time.Unix(secs, 0).UTC(), nil
+		}
+		return time.Time{}, fmt.Errorf("unrecognized timestamp %q", val)
+	case float64:
+		return time.Unix(int64(val), 0).UTC(), nil
+	case int64:
+		return time.Unix(val, 0).UTC(), nil
+	case int:
+		return time.Unix(int64(val), 0).UTC(), nil
+	case json.Number:
+		secs, err := val.Int64()
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unrecognized timestamp %q", val)
+		}
+		return time.Unix(secs, 0).UTC(), nil
+	default:
+		return time.Time{}, fmt.Errorf("unsupported timestamp type %T", v)
+	}
+}
+
+// parseTimeString parses a timestamp string in one of the AR REST formats.
+// Unlike parseTimeValue it does not accept epoch seconds, so numeric
+// strings are not mistaken for timestamps.
+func parseTimeString(s string) (time.Time, bool) {
+	for _, layout := range arTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// numericValue converts a numeric value from Entry.Values to float64.
+// JSON decoding yields float64; Go callers may supply any integer or float type.
+func numericValue(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// formatTimeValue formats a time in the AR REST timestamp format.
+func formatTimeValue(t time.Time) string {
+	return t.Format(arTimeLayouts[0])
+}
@@ -0,0 +1,257 @@
+package remedy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultWaitTimeout is how long WaitFor waits for a condition.
+	defaultWaitTimeout = 30 * time.Second
+
+	// defaultWaitInitialDelay is the delay before the second check of WaitFor.
+	defaultWaitInitialDelay = 250 * time.Millisecond
+
+	// defaultWaitMaxDelay caps the delay between checks of WaitFor.
+	defaultWaitMaxDelay = 5 * time.Second
+)
+
+var (
+	// ErrWaitTimeout indicates an entry did not reach the awaited state in time.
+	ErrWaitTimeout = errors.New("remedy: timed out waiting for entry")
+
+	// ErrInvalidWaitCondition indicates a WaitCondition with neither a
+	// predicate nor a qualification.
+	ErrInvalidWaitCondition = errors.New("remedy: invalid wait condition")
+)
+
+// WaitTimeoutError describes an entry that did not reach the awaited state.
+// It matches ErrWaitTimeout with errors.Is.
+type WaitTimeoutError struct {
+	EntryID   string
+	Condition string
+	Attempts  int
+
+	// Last is the entry as last observed, or nil if it could not be read.
+	Last *Entry
+}
+
+// Error implements the error interface.
+func (e *WaitTimeoutError) Error() string {
+	msg := fmt.Sprintf("remedy: timed out waiting for entry %s to match %s after %d attempts",
+		e.EntryID, e.Condition, e.Attempts)
+	if e.Last == nil {
+		return msg
+	}
+
+	values := make([]string, 0, len(e.Last.Values))
+	for _, field := range slices.Sorted(maps.Keys(e.Last.Values)) {
+		values = append(values, fmt.Sprintf("%s=%v", field, e.Last.Values[field]))
+	}
+
+	return msg + "; last values: " + strings.Join(values, ", ")
+}
+
+// Is implements errors.Is support for WaitTimeoutError.
+func (e *WaitTimeoutError) Is(target error) bool {
+	return errors.Is(target, ErrWaitTimeout)
+}
+
+// WaitCondition is the state WaitFor waits for. Create one with WaitUntil,
+// WaitUntilSet or WaitUntilQuery.
+type WaitCondition struct {
+	match         func(*Entry) bool
+	qualification string
+	description   string
+}
+
+// WaitUntil waits until fn returns true for the entry.
+func WaitUntil(fn func(*Entry) bool) WaitCondition {
+	return WaitCondition{match: fn, description: "predicate"}
+}
+
+// WaitUntilSet waits until all fields have non-empty values, such as
+// Incident Number or Assignee populated by workflow.
+func WaitUntilSet(fields ...string) WaitCondition {
+	return WaitCondition{
+		match: func(e *Entry) bool {
+			for _, field := range fields {
+				if isEmptyValue(e.Values[field]) {
+					return false
+				}
+			}
+			return true
+		},
+		description: "fields set: " + strings.Join(fields, ", "),
+	}
+}
+
+// WaitUntilQuery waits until the entry matches an AR qualification. The
+// qualification is evaluated by the server, combined with the entry's ID.
+func WaitUntilQuery(qualification string) WaitCondition {
+	return WaitCondition{qualification: qualification, description: qualification}
+}
+
+// WaitOption configures WaitFor.
+type WaitOption func(*waitOptions)
+
+// waitOptions holds the configuration for WaitFor.
+type waitOptions struct {
+	timeout      time.Duration
+	initialDelay time.Duration
+	maxDelay     time.Duration
+	fields       []string
+	idField      string
+}
+
+// WithWaitTimeout sets how long WaitFor waits. The default is 30 seconds.
+func WithWaitTimeout(d time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.timeout = d
+	}
+}
+
+// WithWaitBackoff sets the delay before the second check, which doubles
+// after each check up to maxDelay. The defaults are 250ms and 5s and are
+// used for non-positive values.
+func WithWaitBackoff(initial, maxDelay time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		if initial > 0 {
+			o.initialDelay = initial
+		}
+		if maxDelay > 0 {
+			o.maxDelay = maxDelay
+		}
+	}
+}
+
+// WithWaitFields limits the fields read on each check.
+// The entry returned by WaitFor contains only these fields.
+func WithWaitFields(fields ...string) WaitOption {
+	return func(o *waitOptions) {
+		o.fields = fields
+	}
+}
+
+// WithWaitIDField sets the field holding the entry ID in WaitUntilQuery
+// qualifications. The default is the entry ID field (field 1).
+func WithWaitIDField(field string) WaitOption {
+	return func(o *waitOptions) {
+		o.idField = field
+	}
+}
+
+// WaitFor reads an entry repeatedly, with exponential backoff, until it
+// matches cond, and returns the matching entry. It is useful after Create
+// or Update when filters and escalations populate fields asynchronously.
+//
+// Example usage:
+//
+//	entry, err := remedy.WaitFor(ctx, client.Entries(), "HPD:Help Desk", id,
+//	    remedy.WaitUntilSet("Incident Number", "Assigned Group"),
+//	    remedy.WithWaitTimeout(time.Minute))
+//
+// If the entry does not match in time, WaitFor returns a *WaitTimeoutError
+// holding the last observed values. Errors reading the entry and context
+// cancellation are returned as they occur. A condition without a predicate
+// or qualification, such as WaitUntil(nil), returns ErrInvalidWaitCondition.
+func WaitFor(ctx context.Context, entries EntryServicer, form, entryID string, cond WaitCondition, opts ...WaitOption) (*Entry, error) {
+	if cond.match == nil && cond.qualification == "" {
+		return nil, ErrInvalidWaitCondition
+	}
+
+	o := &waitOptions{
+		timeout:      defaultWaitTimeout,
+		initialDelay: defaultWaitInitialDelay,
+		maxDelay:     defaultWaitMaxDelay,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if cond.qualification != "" {
+		idField, err := idFieldOf(ctx, entries, form, o.idField)
+		if err != nil {
+			return nil, err
+		}
+		o.idField = idField
+	}
+
+	deadline := time.Now().Add(o.timeout)
+	delay := o.initialDelay
+
+	for attempt := 1; ; attempt++ {
+		entry, matched, err := checkWait(ctx, entries, form, entryID, cond, o)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			return entry, nil
+		}
+
+		if time.Now().Add(delay).After(deadline) {
+			return nil, waitTimeout(ctx, entries, form, entryID, cond, o, attempt, entry)
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+		delay = min(delay*2, o.maxDelay)
+	}
+}
+
+// checkWait reads the entry and reports whether it matches cond. For query
+// conditions, the entry is only returned when it matches.
+func checkWait(ctx context.Context, entries EntryServicer, form, entryID string, cond WaitCondition, o *waitOptions) (*Entry, bool, error) {
+	if cond.qualification == "" {
+		entry, err := entries.Get(ctx, form, entryID, WithFields(o.fields...))
+		if err != nil {
+			return nil, false, err
+		}
+		return entry, cond.match(entry), nil
+	}
+
+	q := NewQuery().And(o.idField, OpEqual, entryID).Raw(cond.qualification).Build()
+	list, err := entries.List(ctx, form, WithQualification(q), WithFields(o.fields...), WithLimit(1))
+	if err != nil {
+		return nil, false, err
+	}
+	if len(list.Entries) == 0 {
+		return nil, false, nil
+	}
+
+	return &list.Entries[0], true, nil
+}
+
+// waitTimeout builds the timeout error, reading the entry for its last
+// values when the condition was evaluated by the server.
+func waitTimeout(ctx context.Context, entries EntryServicer, form, entryID string, cond WaitCondition, o *waitOptions, attempts int, last *Entry) error {
+	if last == nil {
+		last, _ = entries.Get(ctx, form, entryID, WithFields(o.fields...)) // best effort
+	}
+
+	return &WaitTimeoutError{
+		EntryID:   entryID,
+		Condition: cond.description,
+		Attempts:  attempts,
+		Last:      last,
+	}
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
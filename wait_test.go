@@ -0,0 +1,134 @@
+package remedy
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntryService_WaitFor_UntilSet(t *testing.T) {
+	requests := 0
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		requests++
+		assert.Equal(t, "/api/arsys/v1/entry/HPD:Help Desk/REQ1", req.URL.Path)
+
+		values := map[string]any{"Status": "New", "Incident Number": nil}
+		if requests == 3 {
+			values["Incident Number"] = "INC000000000042"
+		}
+		return newMockResponse(http.StatusOK, Entry{Values: values}), nil
+	})
+
+	entry, err := WaitFor(t.Context(), client.Entries(), "HPD:Help Desk", "REQ1",
+		WaitUntilSet("Incident Number"),
+		WithWaitBackoff(time.Millisecond, 2*time.Millisecond))
+
+	require.NoError(t, err)
+	assert.Equal(t, "INC000000000042", entry.Values["Incident Number"])
+	assert.Equal(t, 3, requests)
+}
+
+func TestEntryService_WaitFor_Timeout(t *testing.T) {
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "values(Status)", req.URL.Query().Get("fields"))
+		return newMockResponse(http.StatusOK, Entry{Values: map[string]any{"Status": "New"}}), nil
+	})
+
+	_, err := WaitFor(t.Context(), client.Entries(), "Form", "REQ1",
+		WaitUntil(func(e *Entry) bool { return e.Values["Status"] == "Assigned" }),
+		WithWaitFields("Status"),
+		WithWaitTimeout(20*time.Millisecond),
+		WithWaitBackoff(time.Millisecond, 5*time.Millisecond))
+
+	require.ErrorIs(t, err, ErrWaitTimeout)
+
+	var timeoutErr *WaitTimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, "REQ1", timeoutErr.EntryID)
+	assert.Greater(t, timeoutErr.Attempts, 1)
+	assert.Contains(t, err.Error(), "last values: Status=New")
+}
+
+func TestEntryService_WaitFor_Query(t *testing.T) {
+	requests := 0
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		requests++
+		assert.Equal(t, "/api/arsys/v1/entry/Form", req.URL.Path)
+		assert.Equal(t, `'Request ID' = "REQ1" AND ('Status' = "Assigned")`, req.URL.Query().Get("q"))
+		assert.Equal(t, "1", req.URL.Query().Get("limit"))
+
+		if requests < 2 {
+			return newMockResponse(http.StatusOK, EntryList{}), nil
+		}
+		return newMockResponse(http.StatusOK, EntryList{Entries: []Entry{
+			{Values: map[string]any{"Request ID": "REQ1", "Status": "Assigned"}},
+		}}), nil
+	})
+
+	entry, err := WaitFor(t.Context(), client.Entries(), "Form", "REQ1",
+		WaitUntilQuery(`'Status' = "Assigned"`),
+		WithWaitBackoff(time.Millisecond, time.Millisecond))
+
+	require.NoError(t, err)
+	assert.Equal(t, "Assigned", entry.Values["Status"])
+}
+
+func TestEntryService_WaitFor_QueryTimeoutReportsValues(t *testing.T) {
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/api/arsys/v1/entry/Form/REQ1" {
+			return newMockResponse(http.StatusOK, Entry{Values: map[string]any{"Status": "New"}}), nil
+		}
+		return newMockResponse(http.StatusOK, EntryList{}), nil
+	})
+
+	_, err := WaitFor(t.Context(), client.Entries(), "Form", "REQ1",
+		WaitUntilQuery(`'Status' = "Assigned"`),
+		WithWaitTimeout(0))
+
+	var timeoutErr *WaitTimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, 1, timeoutErr.Attempts)
+	require.NotNil(t, timeoutErr.Last)
+	assert.Equal(t, "New", timeoutErr.Last.Values["Status"])
+}
+
+func TestEntryService_WaitFor_ContextCancelled(t *testing.T) {
+	client := setupFormClient(t, nil, func(_ *http.Request) (*http.Response, error) {
+		return newMockResponse(http.StatusOK, Entry{Values: map[string]any{}}), nil
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := WaitFor(ctx, client.Entries(), "Form", "REQ1",
+		WaitUntilSet("Incident Number"),
+		WithWaitBackoff(time.Hour, time.Hour),
+		WithWaitTimeout(2*time.Hour))
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestEntryService_WaitFor_InvalidCondition(t *testing.T) {
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request %s", req.URL)
+		return newMockResponse(http.StatusOK, Entry{}), nil
+	})
+
+	for _, cond := range []WaitCondition{{}, WaitUntil(nil)} {
+		_, err := WaitFor(t.Context(), client.Entries(), "Form", "REQ1", cond)
+		require.ErrorIs(t, err, ErrInvalidWaitCondition)
+	}
+}
+
+func TestWithWaitBackoff_NonPositive(t *testing.T) {
+	o := &waitOptions{initialDelay: defaultWaitInitialDelay, maxDelay: defaultWaitMaxDelay}
+
+	WithWaitBackoff(0, -time.Second)(o)
+
+	assert.Equal(t, defaultWaitInitialDelay, o.initialDelay)
+	assert.Equal(t, defaultWaitMaxDelay, o.maxDelay)
+}
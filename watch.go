@@ -0,0 +1,566 @@
+package remedy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultPollInterval is the time between polls of Watch.
+	defaultPollInterval = 30 * time.Second
+
+	// defaultOverlap is how far before its high-water mark Watch re-reads.
+	defaultOverlap = time.Minute
+)
+
+// ChangeType identifies the kind of change reported by Watch.
+type ChangeType int
+
+const (
+	// ChangeCreated reports an entry that has not been modified since it was created.
+	ChangeCreated ChangeType = iota + 1
+
+	// ChangeUpdated reports a modification of an existing entry.
+	ChangeUpdated
+
+	// ChangeDeleted reports an entry found missing by reconciliation.
+	// Only ID is set.
+	ChangeDeleted
+)
+
+// String returns the name of the change type.
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeCreated:
+		return "created"
+	case ChangeUpdated:
+		return "updated"
+	case ChangeDeleted:
+		return "deleted"
+	default:
+		return "unknown"
+	}
+}
+
+// ChangeEvent is a change detected by Watch. If Err is set, the event
+// reports a failed poll and the other fields are empty.
+type ChangeEvent struct {
+	Type     ChangeType
+	ID       string
+	Modified time.Time
+	Entry    *Entry
+	Err      error
+}
+
+// WatchOption configures Watch.
+type WatchOption func(*watchOptions)
+
+// watchOptions holds the configuration for Watch.
+type watchOptions struct {
+	interval      time.Duration
+	overlap       time.Duration
+	from          time.Time
+	query         []QueryOption
+	idField       string
+	modifiedField string
+	createField   string
+	checkpoints   CheckpointStore
+	checkpointKey string
+	reconcile     time.Duration
+}
+
+// WithPollInterval sets the time between polls. The default is 30 seconds.
+func WithPollInterval(d time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.interval = d
+	}
+}
+
+// WithOverlap sets how far before the newest change already seen each poll
+// starts reading, to catch changes committed late or stamped by a server
+// clock that lags behind. Changes seen again within the window are not
+// reported twice. The window never reaches before the position the watch
+// started from. The default is one minute.
+func WithOverlap(d time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.overlap = d
+	}
+}
+
+// WithWatchFrom reports changes made since t when no checkpoint exists.
+// By default Watch starts from the current time.
+func WithWatchFrom(t time.Time) WatchOption {
+	return func(o *watchOptions) {
+		o.from = t
+	}
+}
+
+// WithWatchQuery applies query options to every poll. A qualification set
+// with WithQualification restricts the watched entries, WithFields selects
+// the returned fields and WithLimit sets the page size.
+func WithWatchQuery(opts ...QueryOption) WatchOption {
+	return func(o *watchOptions) {
+		o.query = opts
+	}
+}
+
+// WithWatchIDField sets the unique field used as tie-breaker between
+// entries modified in the same second. The default is the entry ID
+// (field 1).
+func WithWatchIDField(field string) WatchOption {
+	return func(o *watchOptions) {
+		o.idField = field
+	}
+}
+
+// WithWatchCheckpoint persists the high-water mark in store under key after
+// each page of changes, so a restarted watcher continues where it stopped.
+func WithWatchCheckpoint(store CheckpointStore, key string) WatchOption {
+	return func(o *watchOptions) {
+		o.checkpoints = store
+		o.checkpointKey = key
+	}
+}
+
+// WithReconcile detects deleted entries, which polling on Modified Date
+// cannot see. Every interval, the watcher scans the IDs of all watched
+// entries with ID-only pages, compares them with the IDs found by the
+// previous scan and sends a ChangeDeleted event for each missing ID.
+// Entries that stop matching the WithWatchQuery qualification are also
+// reported as deleted.
+//
+// The first scan records the baseline without sending events. With
+// WithWatchCheckpoint, the ID set is saved in the same store under the
+// checkpoint key with an ".ids" suffix, so deletions made while the
+// watcher was stopped are reported after a restart. The set is stored
+// in the compact IDSet encoding.
+func WithReconcile(interval time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.reconcile = interval
+	}
+}
+
+// watchState is the high-water mark of a watcher and the changes already
+// reported within the overlap window. It is persisted as a checkpoint.
+type watchState struct {
+	Modified int64  `json:"modified"`
+	ID       string `json:"id"`
+
+	// Start is the position the watch started from when no checkpoint
+	// existed. The overlap window never reaches before it, so changes made
+	// before the start are not reported.
+	Start int64 `json:"start,omitempty"`
+
+	// Seen maps change keys to their modification time in Unix seconds.
+	Seen map[string]int64 `json:"seen,omitempty"`
+}
+
+// Watch polls form for created and modified entries and sends a ChangeEvent
+// for each on the returned channel, oldest first. The channel is closed when
+// ctx is done.
+//
+// Example usage:
+//
+//	events := remedy.Watch(ctx, client.Entries(), "HPD:Help Desk",
+//	    remedy.WithPollInterval(15*time.Second),
+//	    remedy.WithWatchCheckpoint(remedy.NewFileCheckpointStore("checkpoints"), "hpd-watch"),
+//	)
+//	for event := range events {
+//	    if event.Err != nil {
+//	        log.Printf("poll failed: %v", event.Err)
+//	        continue
+//	    }
+//	    log.Printf("%s %s", event.Type, event.ID)
+//	}
+//
+// Each poll requests entries whose Modified Date (field 6) is within the
+// overlap window before the newest change seen, but not before the start
+// position, sorted by Modified Date and
+// ID, and pages with the qualification
+// `'Modified Date' > t OR ('Modified Date' = t AND 'Request ID' > "id")`.
+// A change is reported once per entry and Modified Date. An entry whose
+// Create Date equals its Modified Date is reported as created. The names
+// of these fields and of the ID field are read from the form metadata, so
+// the Last Modified Date and Submit Date of ITSM forms are used there.
+//
+// Failed polls are reported as events with Err set and retried at the next
+// interval. If the form metadata or the checkpoint cannot be loaded, a
+// single error event is sent and the channel is closed.
+func Watch(ctx context.Context, entries EntryServicer, form string, opts ...WatchOption) <-chan ChangeEvent {
+	o := &watchOptions{
+		interval: defaultPollInterval,
+		overlap:  defaultOverlap,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.interval <= 0 {
+		o.interval = defaultPollInterval
+	}
+
+	events := make(chan ChangeEvent)
+	w := &watcher{entries: entries, form: form, o: o, events: events}
+
+	go w.run(ctx)
+
+	return events
+}
+
+// watcher is the state of a running Watch.
+type watcher struct {
+	entries EntryServicer
+	form    string
+	o       *watchOptions
+	events  chan<- ChangeEvent
+	state   *watchState
+
+	// ids is the ID set found by the last reconciliation, or nil.
+	ids           *IDSet
+	lastReconcile time.Time
+}
+
+// run polls until ctx is done.
+func (w *watcher) run(ctx context.Context) {
+	defer close(w.events)
+
+	if err := w.load(ctx); err != nil {
+		w.send(ctx, ChangeEvent{Err: err})
+		return
+	}
+
+	ticker := time.NewTicker(w.o.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.poll(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			w.send(ctx, ChangeEvent{Err: err})
+		}
+
+		if err := w.maybeReconcile(ctx); err != nil && ctx.Err() == nil {
+			w.send(ctx, ChangeEvent{Err: err})
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// load resolves the core field names and restores the high-water mark and
+// the reconciliation ID set.
+func (w *watcher) load(ctx context.Context) error {
+	core, err := coreFieldsOf(ctx, w.entries, w.form)
+	if err != nil {
+		return err
+	}
+	if w.o.idField == "" {
+		w.o.idField = core.id
+	}
+	w.o.modifiedField, w.o.createField = core.modified, core.created
+
+	state, err := w.o.loadCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	w.state = state
+
+	if w.o.reconcile <= 0 {
+		return nil
+	}
+
+	ids, found, err := w.o.loadIDSet(ctx)
+	if err != nil {
+		return err
+	}
+	if found {
+		w.ids = ids
+	}
+
+	return nil
+}
+
+// send delivers an event unless ctx is done.
+func (w *watcher) send(ctx context.Context, event ChangeEvent) bool {
+	select {
+	case w.events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// poll reads the changes after the high-water mark, minus the overlap.
+func (w *watcher) poll(ctx context.Context) error {
+	baseOpts := &queryOptions{}
+	for _, opt := range w.o.query {
+		opt(baseOpts)
+	}
+
+	pageSize := baseOpts.limit
+	if pageSize <= 0 {
+		pageSize = defaultScanPageSize
+	}
+
+	fields := baseOpts.fields
+	if len(fields) > 0 {
+		fields = slices.Clone(fields)
+		for _, f := range []string{w.o.modifiedField, w.o.createField, w.o.idField} {
+			if !slices.Contains(fields, f) {
+				fields = append(fields, f)
+			}
+		}
+	}
+
+	cursor := NewQuery().And(w.o.modifiedField, OpGreaterEqual, w.lower()).Build()
+
+	for {
+		q := NewQuery()
+		if baseOpts.qualification != "" {
+			q.Raw(baseOpts.qualification)
+		}
+
+		list, err := w.entries.List(ctx, w.form, append(slices.Clone(w.o.query),
+			WithQualification(q.Raw(cursor).Build()),
+			WithFields(fields...),
+			WithSortBy(SortKey{Field: w.o.modifiedField}, SortKey{Field: w.o.idField}),
+			WithLimit(pageSize),
+			WithOffset(0),
+		)...)
+		if err != nil {
+			return fmt.Errorf("polling %s: %w", w.form, err)
+		}
+
+		modified, id, err := w.deliver(ctx, list.Entries)
+		if err != nil {
+			return err
+		}
+
+		last := len(list.Entries) < pageSize
+		if last {
+			w.prune()
+		}
+
+		if err := w.o.saveCheckpoint(ctx, w.state); err != nil {
+			return err
+		}
+
+		if last {
+			return nil
+		}
+
+		cursor = w.o.changeCursor(modified, id)
+	}
+}
+
+// deliver sends the unseen changes of a page and advances the high-water
+// mark. It returns the position of the last entry of the page.
+func (w *watcher) deliver(ctx context.Context, page []Entry) (int64, string, error) {
+	var modified int64
+	var id string
+
+	for i := range page {
+		e := &page[i]
+
+		t, err := modifiedDate(e, w.o.modifiedField)
+		if err != nil {
+			return 0, "", err
+		}
+		modified, id = t.Unix(), e.id(w.o.idField)
+
+		key := id + "@" + strconv.FormatInt(modified, 10)
+		if _, seen := w.state.Seen[key]; seen {
+			continue
+		}
+
+		event := ChangeEvent{Type: ChangeUpdated, ID: id, Modified: t, Entry: e}
+		if created, err := parseTimeValue(e.Values[w.o.createField]); err == nil && created.Unix() == modified {
+			event.Type = ChangeCreated
+		}
+
+		if !w.send(ctx, event) {
+			return 0, "", ctx.Err()
+		}
+
+		w.state.Seen[key] = modified
+		if modified > w.state.Modified || (modified == w.state.Modified && id > w.state.ID) {
+			w.state.Modified, w.state.ID = modified, id
+		}
+	}
+
+	return modified, id, nil
+}
+
+// prune forgets changes older than the overlap window, which later polls
+// no longer read.
+func (w *watcher) prune() {
+	lower := w.lower()
+	for key, modified := range w.state.Seen {
+		if modified < lower {
+			delete(w.state.Seen, key)
+		}
+	}
+}
+
+// lower returns the oldest modification time read by a poll: the overlap
+// window before the high-water mark, but not before the start position.
+func (w *watcher) lower() int64 {
+	return max(w.state.Modified-int64(w.o.overlap/time.Second), w.state.Start)
+}
+
+// maybeReconcile reconciles if enabled and the interval has passed.
+func (w *watcher) maybeReconcile(ctx context.Context) error {
+	if w.o.reconcile <= 0 || time.Since(w.lastReconcile) < w.o.reconcile {
+		return nil
+	}
+
+	if err := w.reconcileIDs(ctx); err != nil {
+		return fmt.Errorf("reconciling %s: %w", w.form, err)
+	}
+	w.lastReconcile = time.Now()
+
+	return nil
+}
+
+// reconcileIDs scans the current IDs and reports the missing ones as deleted.
+func (w *watcher) reconcileIDs(ctx context.Context) error {
+	baseOpts := &queryOptions{}
+	for _, opt := range w.o.query {
+		opt(baseOpts)
+	}
+
+	var b IDSetBuilder
+	err := scanIDs(ctx, w.entries, w.form, baseOpts.qualification, w.o.idField, baseOpts.limit, func(ids []string) error {
+		for _, id := range ids {
+			b.Add(id)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	current := b.Build()
+
+	if w.ids != nil {
+		for id := range w.ids.Difference(current) {
+			if !w.send(ctx, ChangeEvent{Type: ChangeDeleted, ID: id}) {
+				return ctx.Err()
+			}
+		}
+	}
+	w.ids = current
+
+	return w.o.saveIDSet(ctx, current)
+}
+
+// changeCursor returns the qualification for entries after the given
+// modification time and ID.
+func (o *watchOptions) changeCursor(modified int64, id string) string {
+	after := NewQuery().And(o.modifiedField, OpGreaterThan, modified).Build()
+	tie := NewQuery().And(o.modifiedField, OpEqual, modified).And(o.idField, OpGreaterThan, id).Build()
+
+	return after + " OR (" + tie + ")"
+}
+
+// loadCheckpoint returns the saved high-water mark, or the start position.
+func (o *watchOptions) loadCheckpoint(ctx context.Context) (*watchState, error) {
+	from := o.from
+	if from.IsZero() {
+		from = time.Now()
+	}
+	start := &watchState{Modified: from.Unix(), Start: from.Unix(), Seen: make(map[string]int64)}
+
+	if o.checkpoints == nil {
+		return start, nil
+	}
+
+	data, err := o.checkpoints.Load(ctx, o.checkpointKey)
+	if errors.Is(err, ErrNoCheckpoint) {
+		return start, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading watch checkpoint: %w", err)
+	}
+
+	state := &watchState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("decoding watch checkpoint: %w", err)
+	}
+	if state.Seen == nil {
+		state.Seen = make(map[string]int64)
+	}
+
+	return state, nil
+}
+
+// saveCheckpoint persists the high-water mark.
+func (o *watchOptions) saveCheckpoint(ctx context.Context, state *watchState) error {
+	if o.checkpoints == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encoding watch checkpoint: %w", err)
+	}
+
+	if err := o.checkpoints.Save(ctx, o.checkpointKey, data); err != nil {
+		return fmt.Errorf("saving watch checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// idSetKey returns the checkpoint key of the reconciliation ID set.
+func (o *watchOptions) idSetKey() string {
+	return o.checkpointKey + ".ids"
+}
+
+// loadIDSet returns the saved reconciliation ID set and whether one exists.
+func (o *watchOptions) loadIDSet(ctx context.Context) (*IDSet, bool, error) {
+	if o.checkpoints == nil {
+		return nil, false, nil
+	}
+
+	data, err := o.checkpoints.Load(ctx, o.idSetKey())
+	if errors.Is(err, ErrNoCheckpoint) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("loading watch ID set: %w", err)
+	}
+
+	ids := &IDSet{}
+	if err := ids.UnmarshalBinary(data); err != nil {
+		return nil, false, fmt.Errorf("decoding watch ID set: %w", err)
+	}
+
+	return ids, true, nil
+}
+
+// saveIDSet persists the reconciliation ID set.
+func (o *watchOptions) saveIDSet(ctx context.Context, ids *IDSet) error {
+	if o.checkpoints == nil {
+		return nil
+	}
+
+	data, err := ids.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("encoding watch ID set: %w", err)
+	}
+
+	if err := o.checkpoints.Save(ctx, o.idSetKey(), data); err != nil {
+		return fmt.Errorf("saving watch ID set: %w", err)
+	}
+
+	return nil
+}
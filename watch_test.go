@@ -0,0 +1,298 @@
+package remedy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// changeEntry returns an entry with the given ID, Create Date and Modified Date.
+func changeEntry(id string, created, modified time.Time) Entry {
+	return Entry{Values: map[string]any{
+		"Request ID":    id,
+		"Create Date":   formatTimeValue(created),
+		"Modified Date": formatTimeValue(modified),
+	}}
+}
+
+// scriptedLists answers List requests with the given pages in order, then
+// with empty lists, and records the qualifications.
+type scriptedLists struct {
+	mu      sync.Mutex
+	pages   [][]Entry
+	queries []string
+}
+
+func (s *scriptedLists) handle(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.queries = append(s.queries, req.URL.Query().Get("q"))
+	if len(s.pages) == 0 {
+		return newMockResponse(http.StatusOK, EntryList{}), nil
+	}
+
+	page := s.pages[0]
+	s.pages = s.pages[1:]
+	if page == nil {
+		return newMockResponse(http.StatusServiceUnavailable, nil), nil
+	}
+
+	return newMockResponse(http.StatusOK, EntryList{Entries: page}), nil
+}
+
+// receive reads n events or fails the test after a timeout.
+func receive(t *testing.T, events <-chan ChangeEvent, n int) []ChangeEvent {
+	t.Helper()
+
+	var got []ChangeEvent
+	for range n {
+		select {
+		case event := <-events:
+			got = append(got, event)
+		case <-time.After(2 * time.Second):
+			require.FailNow(t, "timed out waiting for event", "received %d of %d", len(got), n)
+		}
+	}
+
+	return got
+}
+
+func TestWatch(t *testing.T) {
+	from := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	script := &scriptedLists{pages: [][]Entry{
+		{
+			changeEntry("REQ1", from.Add(time.Second), from.Add(time.Second)),
+			changeEntry("REQ2", from.Add(-time.Hour), from.Add(2*time.Second)),
+		},
+	}}
+	client := setupFormClient(t, nil, script.handle)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	events := Watch(ctx, client.Entries(), "Form",
+		WithWatchFrom(from),
+		WithOverlap(time.Minute),
+		WithWatchQuery(WithLimit(2)),
+	)
+
+	got := receive(t, events, 2)
+	assert.Equal(t, ChangeCreated, got[0].Type)
+	assert.Equal(t, "REQ1", got[0].ID)
+	assert.Equal(t, ChangeUpdated, got[1].Type)
+	assert.Equal(t, "REQ2", got[1].ID)
+	assert.Equal(t, from.Add(2*time.Second).Unix(), got[1].Modified.Unix())
+
+	// A full page is followed by a request for the next page.
+	require.Eventually(t, func() bool {
+		script.mu.Lock()
+		defer script.mu.Unlock()
+		return len(script.queries) == 2
+	}, 2*time.Second, time.Millisecond)
+
+	cancel()
+	for range events {
+	}
+
+	modified := strconv.FormatInt(from.Add(2*time.Second).Unix(), 10)
+	assert.Equal(t, []string{
+		"('Modified Date' >= 1709294400)",
+		"('Modified Date' > " + modified + " OR ('Modified Date' = " + modified + " AND 'Request ID' > \"REQ2\"))",
+	}, script.queries)
+}
+
+func TestWatch_CoreFieldsFromMetadata(t *testing.T) {
+	from := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	created := from.Add(time.Second)
+	script := &scriptedLists{pages: [][]Entry{
+		{{Values: map[string]any{
+			"Entry ID":           "INC1",
+			"Submit Date":        formatTimeValue(created),
+			"Last Modified Date": formatTimeValue(created),
+		}}},
+	}}
+	client := setupFormClient(t, itsmCoreFields, script.handle)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	events := Watch(ctx, client.Entries(), "HPD:Help Desk",
+		WithWatchFrom(from),
+		WithOverlap(0),
+	)
+
+	got := receive(t, events, 1)
+	assert.Equal(t, ChangeCreated, got[0].Type)
+	assert.Equal(t, "INC1", got[0].ID)
+
+	cancel()
+	for range events {
+	}
+
+	assert.Equal(t, "('Last Modified Date' >= 1709294400)", script.queries[0])
+}
+
+func TestWatch_DeduplicatesAndResumes(t *testing.T) {
+	from := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	first := changeEntry("REQ1", from, from.Add(time.Second))
+	second := changeEntry("REQ2", from, from.Add(2*time.Second))
+
+	script := &scriptedLists{pages: [][]Entry{
+		{first},
+		{first, second}, // overlapping poll returns REQ1 again
+	}}
+	client := setupFormClient(t, nil, script.handle)
+	store := NewMemoryCheckpointStore()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	events := Watch(ctx, client.Entries(), "Form",
+		WithWatchFrom(from),
+		WithPollInterval(10*time.Millisecond),
+		WithWatchCheckpoint(store, "watch"),
+	)
+
+	got := receive(t, events, 2)
+	assert.Equal(t, "REQ1", got[0].ID)
+	assert.Equal(t, "REQ2", got[1].ID)
+
+	cancel()
+	for range events {
+	}
+
+	data, err := store.Load(t.Context(), "watch")
+	require.NoError(t, err)
+	var state watchState
+	require.NoError(t, json.Unmarshal(data, &state))
+	assert.Equal(t, from.Add(2*time.Second).Unix(), state.Modified)
+	assert.Equal(t, "REQ2", state.ID)
+
+	// A restarted watcher skips changes reported before the restart.
+	third := changeEntry("REQ3", from, from.Add(3*time.Second))
+	script.pages = [][]Entry{{first, second, third}}
+
+	ctx, cancel = context.WithCancel(t.Context())
+	defer cancel()
+	events = Watch(ctx, client.Entries(), "Form", WithWatchCheckpoint(store, "watch"))
+
+	got = receive(t, events, 1)
+	assert.Equal(t, "REQ3", got[0].ID)
+}
+
+func TestWatch_OverlapDoesNotReachBeforeStart(t *testing.T) {
+	from := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	script := &scriptedLists{}
+	client := setupFormClient(t, nil, script.handle)
+	store := NewMemoryCheckpointStore()
+
+	polls := func(n int) []string {
+		t.Helper()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		events := Watch(ctx, client.Entries(), "Form",
+			WithWatchFrom(from),
+			WithOverlap(time.Minute),
+			WithPollInterval(10*time.Millisecond),
+			WithWatchCheckpoint(store, "watch"),
+		)
+
+		require.Eventually(t, func() bool {
+			script.mu.Lock()
+			defer script.mu.Unlock()
+			return len(script.queries) >= n
+		}, 2*time.Second, time.Millisecond)
+
+		cancel()
+		for range events {
+		}
+
+		script.mu.Lock()
+		defer script.mu.Unlock()
+		queries := script.queries
+		script.queries = nil
+
+		return queries
+	}
+
+	// Polls without changes, before and after a restart from the
+	// checkpoint, do not read changes made before the start.
+	for _, q := range append(polls(2), polls(1)...) {
+		assert.Equal(t, "('Modified Date' >= 1709294400)", q)
+	}
+}
+
+func TestWatch_PollError(t *testing.T) {
+	from := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	script := &scriptedLists{pages: [][]Entry{
+		nil, // server error
+		{changeEntry("REQ1", from, from.Add(time.Second))},
+	}}
+	client := setupFormClient(t, nil, script.handle)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	events := Watch(ctx, client.Entries(), "Form",
+		WithWatchFrom(from), WithPollInterval(10*time.Millisecond))
+
+	got := receive(t, events, 2)
+
+	var apiErr *APIError
+	require.ErrorAs(t, got[0].Err, &apiErr)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.Equal(t, "REQ1", got[1].ID)
+}
+
+func TestWatch_Reconcile(t *testing.T) {
+	var mu sync.Mutex
+	ids := []string{"REQ1", "REQ2", "REQ3"}
+	setIDs := func(v ...string) {
+		mu.Lock()
+		defer mu.Unlock()
+		ids = v
+	}
+
+	client := setupFormClient(t, nil, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("sort") != "Request ID" {
+			return newMockResponse(http.StatusOK, EntryList{}), nil // change poll
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return idListResponse(t, req, ids), nil
+	})
+	store := NewMemoryCheckpointStore()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	events := Watch(ctx, client.Entries(), "Form",
+		WithPollInterval(5*time.Millisecond),
+		WithReconcile(time.Nanosecond),
+		WithWatchCheckpoint(store, "watch"),
+	)
+
+	// The baseline scan reports nothing; later scans report missing IDs.
+	require.Eventually(t, func() bool {
+		_, err := store.Load(t.Context(), "watch.ids")
+		return err == nil
+	}, 2*time.Second, time.Millisecond)
+	setIDs("REQ1", "REQ3")
+	got := receive(t, events, 1)
+	assert.Equal(t, ChangeEvent{Type: ChangeDeleted, ID: "REQ2"}, got[0])
+
+	cancel()
+	for range events {
+	}
+
+	// Deletions made while stopped are reported after a restart.
+	setIDs("REQ3")
+	ctx, cancel = context.WithCancel(t.Context())
+	defer cancel()
+	events = Watch(ctx, client.Entries(), "Form",
+		WithReconcile(time.Hour),
+		WithWatchCheckpoint(store, "watch"),
+	)
+
+	got = receive(t, events, 1)
+	assert.Equal(t, ChangeEvent{Type: ChangeDeleted, ID: "REQ1"}, got[0])
+}